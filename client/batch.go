@@ -0,0 +1,49 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+
+	gethrpc "github.com/JFJun/go-substrate-rpc-client/v3/gethrpc"
+)
+
+// DefaultBatchSize is the maximum number of requests sent in one batch by the batch helpers of the rpc packages
+const DefaultBatchSize = 100
+
+// BatchCallInChunks sends the requests in b as consecutive batches of at most chunkSize requests, so that large
+// numbers of requests do not exceed the request or response size limits of a node. A chunkSize of 0 or less means
+// DefaultBatchSize. As with BatchCallContext, errors specific to a request are reported through the Error field of the
+// corresponding BatchElem.
+func BatchCallInChunks(ctx context.Context, c Client, b []gethrpc.BatchElem, chunkSize int) error {
+	if chunkSize <= 0 {
+		chunkSize = DefaultBatchSize
+	}
+
+	for start := 0; start < len(b); start += chunkSize {
+		end := start + chunkSize
+		if end > len(b) {
+			end = len(b)
+		}
+
+		err := c.BatchCallContext(ctx, b[start:end])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -30,6 +30,14 @@ type Client interface {
 	// args must be encoded in the format RPC understands
 	Call(result interface{}, method string, args ...interface{}) error
 
+	// CallContext makes the call to RPC method with the provided args, aborting it if ctx is done before the call
+	// completes
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+
+	// BatchCallContext sends all given requests as a single batch and waits for the server to return a response for
+	// all of them. Errors specific to a request are reported through the Error field of the corresponding BatchElem.
+	BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error
+
 	Subscribe(ctx context.Context, namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
 		notificationMethodSuffix string, channel interface{}, args ...interface{}) (
 		*gethrpc.ClientSubscription, error)
@@ -64,8 +72,14 @@ func Connect(url string) (Client, error) {
 }
 
 func CallWithBlockHash(c Client, target interface{}, method string, blockHash *types.Hash, args ...interface{}) error {
+	return CallWithBlockHashContext(context.Background(), c, target, method, blockHash, args...)
+}
+
+// CallWithBlockHashContext is like CallWithBlockHash, aborting the call if ctx is done before it completes
+func CallWithBlockHashContext(ctx context.Context, c Client, target interface{}, method string, blockHash *types.Hash,
+	args ...interface{}) error {
 	if blockHash == nil {
-		err := c.Call(target, method, args...)
+		err := c.CallContext(ctx, target, method, args...)
 		if err != nil {
 			return err
 		}
@@ -76,7 +90,7 @@ func CallWithBlockHash(c Client, target interface{}, method string, blockHash *t
 		return err
 	}
 	hargs := append(args, hexHash)
-	err = c.Call(target, method, hargs...)
+	err = c.CallContext(ctx, target, method, hargs...)
 	if err != nil {
 		return err
 	}
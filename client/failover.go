@@ -0,0 +1,439 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/config"
+	gethrpc "github.com/JFJun/go-substrate-rpc-client/v3/gethrpc"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// ErrNoHealthyEndpoint is returned by a FailoverClient if none of its endpoints is healthy
+var ErrNoHealthyEndpoint = errors.New("no healthy endpoint")
+
+// FailoverOptions configures the health checks of a FailoverClient
+type FailoverOptions struct {
+	// HealthCheckInterval is the interval in which all endpoints are checked via system_health
+	HealthCheckInterval time.Duration
+	// RehomeBackoff is the delay between attempts to move the subscriptions of a failed endpoint while no other
+	// endpoint is healthy
+	RehomeBackoff time.Duration
+}
+
+// DefaultFailoverOptions returns the default FailoverOptions, checking endpoints every 10 seconds
+func DefaultFailoverOptions() FailoverOptions {
+	return FailoverOptions{
+		HealthCheckInterval: 10 * time.Second,
+		RehomeBackoff:       time.Second,
+	}
+}
+
+// FailoverClient is a Client that distributes calls over several endpoints of the same chain. Endpoints are
+// health-checked via system_health, a node is considered healthy if it is reachable, not syncing and has peers if it
+// should have some. Calls are routed round-robin to healthy endpoints and retried on the next one if the connection
+// fails. Subscriptions are pinned to one endpoint and moved to another one if it fails.
+type FailoverClient struct {
+	endpoints []*failoverEndpoint
+	opts      FailoverOptions
+	next      uint32
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	mu   sync.Mutex
+	subs map[*reconnectingSubscription]*failoverEndpoint
+}
+
+type failoverEndpoint struct {
+	url string
+
+	mu      sync.RWMutex
+	conn    *gethrpc.Client
+	healthy bool
+}
+
+// ConnectWithFailover connects to the provided urls, returning an error if none of them is healthy. Zero values in
+// opts are replaced by the ones of DefaultFailoverOptions.
+func ConnectWithFailover(urls []string, opts FailoverOptions) (*FailoverClient, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no endpoints given")
+	}
+	if opts.HealthCheckInterval <= 0 {
+		opts.HealthCheckInterval = DefaultFailoverOptions().HealthCheckInterval
+	}
+	if opts.RehomeBackoff <= 0 {
+		opts.RehomeBackoff = DefaultFailoverOptions().RehomeBackoff
+	}
+
+	c := &FailoverClient{
+		opts:   opts,
+		closed: make(chan struct{}),
+		subs:   make(map[*reconnectingSubscription]*failoverEndpoint),
+	}
+	for _, url := range urls {
+		c.endpoints = append(c.endpoints, &failoverEndpoint{url: url})
+	}
+
+	c.checkHealth()
+	if len(c.healthyEndpoints()) == 0 {
+		c.Close()
+		return nil, fmt.Errorf("%v among %v", ErrNoHealthyEndpoint, urls)
+	}
+
+	go c.monitor()
+
+	return c, nil
+}
+
+// URL returns the URL of the first healthy endpoint, or of the first endpoint if none is healthy
+func (c *FailoverClient) URL() string {
+	eps := c.healthyEndpoints()
+	if len(eps) == 0 {
+		return c.endpoints[0].url
+	}
+	return eps[0].url
+}
+
+// HealthyURLs returns the URLs of all endpoints that are currently healthy
+func (c *FailoverClient) HealthyURLs() []string {
+	eps := c.healthyEndpoints()
+	urls := make([]string, 0, len(eps))
+	for _, e := range eps {
+		urls = append(urls, e.url)
+	}
+	return urls
+}
+
+// Call makes the call to RPC method with the provided args on the next healthy endpoint. If the connection to it
+// fails, the endpoint is marked unhealthy and the call is retried on the next one.
+func (c *FailoverClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+// CallContext is like Call, aborting the call if ctx is done before it completes
+func (c *FailoverClient) CallContext(ctx context.Context, result interface{}, method string,
+	args ...interface{}) error {
+	err := ErrNoHealthyEndpoint
+	for _, e := range c.nextEndpoints() {
+		conn := e.connection()
+		if conn == nil {
+			continue
+		}
+
+		err = conn.CallContext(ctx, result, method, args...)
+		if !isConnectionError(err) {
+			return err
+		}
+		c.markDown(e, conn)
+	}
+	return err
+}
+
+// BatchCallContext sends all given requests as a single batch to the next healthy endpoint. If the connection to it
+// fails, the endpoint is marked unhealthy and the batch is retried on the next one.
+func (c *FailoverClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	err := ErrNoHealthyEndpoint
+	for _, e := range c.nextEndpoints() {
+		conn := e.connection()
+		if conn == nil {
+			continue
+		}
+
+		err = conn.BatchCallContext(ctx, b)
+		if !isConnectionError(err) {
+			return err
+		}
+		c.markDown(e, conn)
+	}
+	return err
+}
+
+// Subscribe subscribes to the notifications of the given method on the next healthy endpoint. If that endpoint fails,
+// the subscription is moved to another healthy endpoint, its channel and error channel stay the same.
+func (c *FailoverClient) Subscribe(ctx context.Context, namespace, subscribeMethodSuffix,
+	unsubscribeMethodSuffix, notificationMethodSuffix string, channel interface{}, args ...interface{}) (
+	*gethrpc.ClientSubscription, error) {
+	chanVal := reflect.ValueOf(channel)
+	if chanVal.Kind() != reflect.Chan || chanVal.Type().ChanDir()&reflect.SendDir == 0 {
+		panic("sixth argument to Subscribe must be a writable channel")
+	}
+	if chanVal.IsNil() {
+		panic("channel given to Subscribe must not be nil")
+	}
+
+	s := &reconnectingSubscription{
+		namespace:                namespace,
+		subscribeMethodSuffix:    subscribeMethodSuffix,
+		unsubscribeMethodSuffix:  unsubscribeMethodSuffix,
+		notificationMethodSuffix: notificationMethodSuffix,
+		args:                     args,
+		channel:                  chanVal,
+		done:                     make(chan struct{}),
+	}
+	s.lost = func(conn *gethrpc.Client) {
+		c.lost(s, conn)
+	}
+	s.remove = func() {
+		c.mu.Lock()
+		delete(c.subs, s)
+		c.mu.Unlock()
+	}
+	s.sub = gethrpc.NewClientSubscription(s.unsubscribe)
+
+	err := ErrNoHealthyEndpoint
+	for _, e := range c.nextEndpoints() {
+		conn := e.connection()
+		if conn == nil {
+			continue
+		}
+
+		c.mu.Lock()
+		c.subs[s] = e
+		c.mu.Unlock()
+
+		err = s.subscribe(ctx, conn)
+		if err == nil {
+			return s.sub, nil
+		}
+		if !isConnectionError(err) {
+			break
+		}
+		c.markDown(e, conn)
+	}
+
+	s.unsubscribe()
+	return nil, err
+}
+
+// Close closes the connections to all endpoints and ends all active subscriptions, their error channels receive nil
+func (c *FailoverClient) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+
+		c.mu.Lock()
+		subs := c.subs
+		c.subs = make(map[*reconnectingSubscription]*failoverEndpoint)
+		c.mu.Unlock()
+
+		for s := range subs {
+			s.stop()
+			s.sub.Fail(nil)
+		}
+
+		for _, e := range c.endpoints {
+			e.mu.Lock()
+			if e.conn != nil {
+				e.conn.Close()
+			}
+			e.conn, e.healthy = nil, false
+			e.mu.Unlock()
+		}
+	})
+}
+
+func (c *FailoverClient) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// monitor checks the health of all endpoints in the configured interval until the client is closed
+func (c *FailoverClient) monitor() {
+	ticker := time.NewTicker(c.opts.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.closed:
+			return
+		case <-ticker.C:
+			c.checkHealth()
+		}
+	}
+}
+
+// checkHealth checks all endpoints concurrently, dialing the ones that are not connected
+func (c *FailoverClient) checkHealth() {
+	var wg sync.WaitGroup
+	for _, e := range c.endpoints {
+		wg.Add(1)
+		go func(e *failoverEndpoint) {
+			defer wg.Done()
+			c.checkEndpoint(e)
+		}(e)
+	}
+	wg.Wait()
+}
+
+// checkEndpoint checks the health of the connection of the endpoint, whether it is healthy or not, so unhealthy nodes
+// rejoin once they have e.g. finished syncing. Endpoints without a connection are dialed first.
+func (c *FailoverClient) checkEndpoint(e *failoverEndpoint) {
+	e.mu.RLock()
+	conn := e.conn
+	e.mu.RUnlock()
+
+	if conn == nil {
+		var err error
+		conn, err = dial(e.url)
+		if err != nil {
+			return
+		}
+
+		e.mu.Lock()
+		if c.isClosed() || e.conn != nil {
+			e.mu.Unlock()
+			conn.Close()
+			return
+		}
+		e.conn = conn
+		e.mu.Unlock()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Default().DialTimeout)
+	defer cancel()
+
+	var h types.Health
+	err := conn.CallContext(ctx, &h, "system_health")
+	if isConnectionError(err) || ctx.Err() != nil {
+		c.markDown(e, conn)
+		return
+	}
+
+	healthy := err == nil && !h.IsSyncing && (h.Peers > 0 || !h.ShouldHavePeers)
+
+	e.mu.Lock()
+	if e.conn == conn {
+		if e.healthy != healthy {
+			log.Printf("Endpoint %v is healthy: %v", e.url, healthy)
+		}
+		e.healthy = healthy
+	}
+	e.mu.Unlock()
+}
+
+// markDown marks the endpoint unhealthy and closes the failed connection, which moves the subscriptions on it to
+// other endpoints
+func (c *FailoverClient) markDown(e *failoverEndpoint, failed *gethrpc.Client) {
+	e.mu.Lock()
+	if e.conn != failed {
+		e.mu.Unlock()
+		return
+	}
+	log.Printf("Endpoint %v failed", e.url)
+	e.conn, e.healthy = nil, false
+	e.mu.Unlock()
+
+	failed.Close()
+}
+
+// lost is called when the server subscription of s on conn has ended, it moves s to another healthy endpoint
+func (c *FailoverClient) lost(s *reconnectingSubscription, conn *gethrpc.Client) {
+	c.mu.Lock()
+	e, ok := c.subs[s]
+	c.mu.Unlock()
+	if !ok || c.isClosed() {
+		return
+	}
+
+	c.markDown(e, conn)
+	go c.rehome(s)
+}
+
+func (c *FailoverClient) rehome(s *reconnectingSubscription) {
+	for {
+		for _, e := range c.nextEndpoints() {
+			conn := e.connection()
+			if conn == nil {
+				continue
+			}
+
+			c.mu.Lock()
+			_, ok := c.subs[s]
+			if ok {
+				c.subs[s] = e
+			}
+			c.mu.Unlock()
+			if !ok {
+				return
+			}
+
+			err := s.resubscribe(conn)
+			if err == nil {
+				return
+			}
+			log.Printf("Unable to move subscription %v_%v to %v: %v", s.namespace, s.subscribeMethodSuffix, e.url,
+				err)
+			if isConnectionError(err) {
+				c.markDown(e, conn)
+			}
+		}
+
+		select {
+		case <-c.closed:
+			return
+		case <-s.done:
+			return
+		case <-time.After(c.opts.RehomeBackoff):
+		}
+	}
+}
+
+func (c *FailoverClient) healthyEndpoints() []*failoverEndpoint {
+	eps := make([]*failoverEndpoint, 0, len(c.endpoints))
+	for _, e := range c.endpoints {
+		e.mu.RLock()
+		if e.healthy {
+			eps = append(eps, e)
+		}
+		e.mu.RUnlock()
+	}
+	return eps
+}
+
+// nextEndpoints returns the healthy endpoints in round-robin order
+func (c *FailoverClient) nextEndpoints() []*failoverEndpoint {
+	eps := c.healthyEndpoints()
+	if len(eps) == 0 {
+		return nil
+	}
+
+	start := int(atomic.AddUint32(&c.next, 1) % uint32(len(eps)))
+	ordered := make([]*failoverEndpoint, 0, len(eps))
+	ordered = append(ordered, eps[start:]...)
+	return append(ordered, eps[:start]...)
+}
+
+// connection returns the connection of the endpoint if it is healthy
+func (e *failoverEndpoint) connection() *gethrpc.Client {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if !e.healthy {
+		return nil
+	}
+	return e.conn
+}
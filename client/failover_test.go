@@ -0,0 +1,147 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailoverClient_RoutesToHealthyEndpoints(t *testing.T) {
+	a, b, syncing := newStubNode(), newStubNode(), newStubNode()
+	defer a.Close()
+	defer b.Close()
+	defer syncing.Close()
+	a.name, b.name = "a", "b"
+	syncing.setSyncing(true)
+
+	cl, err := ConnectWithFailover([]string{a.URL(), b.URL(), syncing.URL()}, DefaultFailoverOptions())
+	assert.NoError(t, err)
+	defer cl.Close()
+
+	assert.ElementsMatch(t, []string{a.URL(), b.URL()}, cl.HealthyURLs())
+
+	names := map[string]int{}
+	for i := 0; i < 4; i++ {
+		var name string
+		err = cl.Call(&name, "system_name")
+		assert.NoError(t, err)
+		names[name]++
+	}
+	assert.Equal(t, map[string]int{"a": 2, "b": 2}, names)
+}
+
+func TestFailoverClient_SyncedEndpointRejoins(t *testing.T) {
+	a, syncing := newStubNode(), newStubNode()
+	defer a.Close()
+	defer syncing.Close()
+	a.name, syncing.name = "a", "syncing"
+	syncing.setSyncing(true)
+
+	cl, err := ConnectWithFailover([]string{a.URL(), syncing.URL()}, FailoverOptions{
+		HealthCheckInterval: 10 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	defer cl.Close()
+	assert.Equal(t, []string{a.URL()}, cl.HealthyURLs())
+
+	// the node finishes syncing and is checked again on its existing connection
+	syncing.setSyncing(false)
+	timeout := time.After(5 * time.Second)
+	for len(cl.HealthyURLs()) != 2 {
+		select {
+		case <-timeout:
+			t.Fatal("synced endpoint did not rejoin within 5s")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	assert.Equal(t, 1, syncing.connections())
+
+	names := map[string]int{}
+	for i := 0; i < 4; i++ {
+		var name string
+		err = cl.Call(&name, "system_name")
+		assert.NoError(t, err)
+		names[name]++
+	}
+	assert.Equal(t, map[string]int{"a": 2, "syncing": 2}, names)
+}
+
+func TestFailoverClient_FailsOver(t *testing.T) {
+	a, b := newStubNode(), newStubNode()
+	defer a.Close()
+	defer b.Close()
+	a.name, b.name = "a", "b"
+
+	cl, err := ConnectWithFailover([]string{a.URL(), b.URL()}, FailoverOptions{
+		HealthCheckInterval: time.Hour,
+		RehomeBackoff:       10 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	defer cl.Close()
+
+	ch := make(chan int)
+	sub, err := cl.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	assert.Equal(t, 0, <-ch)
+
+	// stop the node the subscription is pinned to
+	pinned, other := a, b
+	if b.subscriptions() == 1 {
+		pinned, other = b, a
+	}
+	pinned.dropConnections()
+	pinned.Close()
+
+	// the subscription moves to the other node, whose notifications start at 0 again
+	timeout := time.After(5 * time.Second)
+	for v := 1; v != 0; {
+		select {
+		case v = <-ch:
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-timeout:
+			t.Fatal("subscription was not moved within 5s")
+		}
+	}
+	assert.Equal(t, 1, other.subscriptions())
+	assert.Equal(t, []string{other.URL()}, cl.HealthyURLs())
+
+	for i := 0; i < 2; i++ {
+		var name string
+		err = cl.Call(&name, "system_name")
+		assert.NoError(t, err)
+		assert.Equal(t, other.name, name)
+	}
+}
+
+func TestConnectWithFailover_NoHealthyEndpoint(t *testing.T) {
+	syncing := newStubNode()
+	defer syncing.Close()
+	syncing.setSyncing(true)
+
+	_, err := ConnectWithFailover([]string{syncing.URL()}, DefaultFailoverOptions())
+	assert.EqualError(t, err, "no healthy endpoint among ["+syncing.URL()+"]")
+
+	_, err = ConnectWithFailover(nil, DefaultFailoverOptions())
+	assert.EqualError(t, err, "no endpoints given")
+}
@@ -0,0 +1,441 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/config"
+	gethrpc "github.com/JFJun/go-substrate-rpc-client/v3/gethrpc"
+)
+
+// ReconnectOptions configures how a ReconnectingClient re-dials a lost connection
+type ReconnectOptions struct {
+	// InitialBackoff is the delay before the first re-dial, it is doubled after every failed attempt
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between two re-dials
+	MaxBackoff time.Duration
+	// MaxAttempts is the number of failed re-dials after which all active subscriptions fail with the last dial
+	// error, 0 re-dials until the client is closed
+	MaxAttempts int
+}
+
+// DefaultReconnectOptions returns the default ReconnectOptions, re-dialing forever with a backoff from 1 to 30 seconds
+func DefaultReconnectOptions() ReconnectOptions {
+	return ReconnectOptions{
+		InitialBackoff: time.Second,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// ReconnectEvent is emitted by a ReconnectingClient after it has re-established a lost connection and its active
+// subscriptions. Notifications sent by the node while the connection was down are lost, so consumers should use it to
+// backfill missed data, e.g. blocks.
+type ReconnectEvent struct {
+	// Attempts is the number of dials it took to reconnect
+	Attempts int
+	// Downtime is the time between the detection of the connection loss and the reconnect
+	Downtime time.Duration
+}
+
+// ReconnectingClient is a Client that re-dials its websocket connection with backoff when it is lost, e.g. because
+// the node restarted. Subscriptions made through it, such as new heads, finalized heads, storage or runtime version
+// subscriptions, are re-established transparently on the new connection, their channels and error channels stay the
+// same. Calls made while the connection is down return an error.
+type ReconnectingClient struct {
+	url        string
+	opts       ReconnectOptions
+	reconnects chan ReconnectEvent
+	closed     chan struct{}
+	closeOnce  sync.Once
+
+	mu           sync.RWMutex
+	conn         *gethrpc.Client
+	reconnecting bool
+	subs         map[*reconnectingSubscription]struct{}
+}
+
+// ConnectWithReconnect connects to the provided url, returning a client that reconnects according to opts whenever
+// the connection is lost. Zero backoffs in opts are replaced by the ones of DefaultReconnectOptions.
+func ConnectWithReconnect(url string, opts ReconnectOptions) (*ReconnectingClient, error) {
+	log.Printf("Connecting to %v...", url)
+
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = DefaultReconnectOptions().InitialBackoff
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultReconnectOptions().MaxBackoff
+	}
+
+	conn, err := dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReconnectingClient{
+		url:        url,
+		opts:       opts,
+		reconnects: make(chan ReconnectEvent, 16),
+		closed:     make(chan struct{}),
+		conn:       conn,
+		subs:       make(map[*reconnectingSubscription]struct{}),
+	}, nil
+}
+
+func dial(url string) (*gethrpc.Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.Default().DialTimeout)
+	defer cancel()
+
+	return gethrpc.DialContext(ctx, url)
+}
+
+// URL returns the URL the client connects to
+func (c *ReconnectingClient) URL() string {
+	return c.url
+}
+
+// Reconnects returns a channel that receives an event every time the client has reconnected. The channel is buffered,
+// events are dropped if it is full.
+func (c *ReconnectingClient) Reconnects() <-chan ReconnectEvent {
+	return c.reconnects
+}
+
+// Call makes the call to RPC method with the provided args on the current connection. If the call fails because the
+// connection is lost, the client starts to reconnect in the background.
+func (c *ReconnectingClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+// CallContext is like Call, aborting the call if ctx is done before it completes
+func (c *ReconnectingClient) CallContext(ctx context.Context, result interface{}, method string,
+	args ...interface{}) error {
+	conn := c.currentConn()
+
+	err := conn.CallContext(ctx, result, method, args...)
+	if isConnectionError(err) {
+		c.reconnect(conn)
+	}
+	return err
+}
+
+// BatchCallContext sends all given requests as a single batch on the current connection
+func (c *ReconnectingClient) BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error {
+	conn := c.currentConn()
+
+	err := conn.BatchCallContext(ctx, b)
+	if isConnectionError(err) {
+		c.reconnect(conn)
+	}
+	return err
+}
+
+// Subscribe subscribes to the notifications of the given method on the current connection. The returned
+// subscription is re-established on every new connection until it is unsubscribed or the client is closed.
+func (c *ReconnectingClient) Subscribe(ctx context.Context, namespace, subscribeMethodSuffix,
+	unsubscribeMethodSuffix, notificationMethodSuffix string, channel interface{}, args ...interface{}) (
+	*gethrpc.ClientSubscription, error) {
+	chanVal := reflect.ValueOf(channel)
+	if chanVal.Kind() != reflect.Chan || chanVal.Type().ChanDir()&reflect.SendDir == 0 {
+		panic("sixth argument to Subscribe must be a writable channel")
+	}
+	if chanVal.IsNil() {
+		panic("channel given to Subscribe must not be nil")
+	}
+
+	s := &reconnectingSubscription{
+		lost:                     c.reconnect,
+		namespace:                namespace,
+		subscribeMethodSuffix:    subscribeMethodSuffix,
+		unsubscribeMethodSuffix:  unsubscribeMethodSuffix,
+		notificationMethodSuffix: notificationMethodSuffix,
+		args:                     args,
+		channel:                  chanVal,
+		done:                     make(chan struct{}),
+	}
+	s.remove = func() {
+		c.mu.Lock()
+		delete(c.subs, s)
+		c.mu.Unlock()
+	}
+	s.sub = gethrpc.NewClientSubscription(s.unsubscribe)
+
+	// register the subscription first, so it is re-established by a reconnect that happens while subscribing
+	c.mu.Lock()
+	c.subs[s] = struct{}{}
+	c.mu.Unlock()
+
+	conn := c.currentConn()
+	err := s.subscribe(ctx, conn)
+	if err != nil {
+		s.unsubscribe()
+		if isConnectionError(err) {
+			c.reconnect(conn)
+		}
+		return nil, err
+	}
+
+	return s.sub, nil
+}
+
+// Close closes the connection and ends all active subscriptions, their error channels receive nil
+func (c *ReconnectingClient) Close() {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+
+		c.mu.Lock()
+		conn := c.conn
+		subs := c.subs
+		c.subs = make(map[*reconnectingSubscription]struct{})
+		c.mu.Unlock()
+
+		for s := range subs {
+			s.stop()
+			s.sub.Fail(nil)
+		}
+		conn.Close()
+	})
+}
+
+func (c *ReconnectingClient) currentConn() *gethrpc.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.conn
+}
+
+func (c *ReconnectingClient) isClosed() bool {
+	select {
+	case <-c.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// reconnect starts to re-dial in the background if failed is still the current connection and no reconnect is in
+// progress yet
+func (c *ReconnectingClient) reconnect(failed *gethrpc.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.isClosed() || c.conn != failed || c.reconnecting {
+		return
+	}
+	c.reconnecting = true
+
+	go c.redial(failed)
+}
+
+func (c *ReconnectingClient) redial(failed *gethrpc.Client) {
+	lost := time.Now()
+	backoff := c.opts.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-c.closed:
+			return
+		case <-time.After(backoff):
+		}
+
+		log.Printf("Reconnecting to %v (attempt %v)...", c.url, attempt)
+
+		conn, err := dial(c.url)
+		if err != nil {
+			if c.opts.MaxAttempts > 0 && attempt >= c.opts.MaxAttempts {
+				c.giveUp(fmt.Errorf("unable to reconnect to %v after %v attempts: %v", c.url, attempt, err))
+				return
+			}
+			backoff *= 2
+			if backoff > c.opts.MaxBackoff {
+				backoff = c.opts.MaxBackoff
+			}
+			continue
+		}
+
+		c.mu.Lock()
+		if c.isClosed() {
+			c.mu.Unlock()
+			conn.Close()
+			return
+		}
+		c.conn = conn
+		c.reconnecting = false
+		subs := make([]*reconnectingSubscription, 0, len(c.subs))
+		for s := range c.subs {
+			subs = append(subs, s)
+		}
+		c.mu.Unlock()
+
+		failed.Close()
+
+		for _, s := range subs {
+			err := s.resubscribe(conn)
+			if err != nil {
+				log.Printf("Unable to resubscribe to %v_%v: %v", s.namespace, s.subscribeMethodSuffix, err)
+				c.reconnect(conn)
+				return
+			}
+		}
+
+		select {
+		case c.reconnects <- ReconnectEvent{Attempts: attempt, Downtime: time.Since(lost)}:
+		default:
+		}
+		return
+	}
+}
+
+// giveUp fails all active subscriptions with err. The next failing call starts a new reconnect.
+func (c *ReconnectingClient) giveUp(err error) {
+	c.mu.Lock()
+	subs := c.subs
+	c.subs = make(map[*reconnectingSubscription]struct{})
+	c.reconnecting = false
+	c.mu.Unlock()
+
+	for s := range subs {
+		s.stop()
+		s.sub.Fail(err)
+	}
+}
+
+// isConnectionError returns true if err is not an error returned by the node or caused by the caller, e.g. by
+// cancelling the context of the call
+func isConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var rpcErr gethrpc.Error
+	return !errors.As(err, &rpcErr)
+}
+
+// reconnectingSubscription forwards the notifications of the server subscription on the current connection to the
+// subscriber's channel. The client owning it is notified through lost when the connection of the server subscription
+// is lost, and through remove when the subscriber unsubscribes.
+type reconnectingSubscription struct {
+	lost                     func(conn *gethrpc.Client)
+	remove                   func()
+	namespace                string
+	subscribeMethodSuffix    string
+	unsubscribeMethodSuffix  string
+	notificationMethodSuffix string
+	args                     []interface{}
+	channel                  reflect.Value
+	sub                      *gethrpc.ClientSubscription
+	done                     chan struct{}
+	doneOnce                 sync.Once
+
+	mu    sync.Mutex
+	inner *gethrpc.ClientSubscription
+}
+
+func (s *reconnectingSubscription) subscribe(ctx context.Context, conn *gethrpc.Client) error {
+	ch := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, s.channel.Type().Elem()), 0)
+
+	inner, err := conn.Subscribe(ctx, s.namespace, s.subscribeMethodSuffix, s.unsubscribeMethodSuffix,
+		s.notificationMethodSuffix, ch.Interface(), s.args...)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.inner = inner
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		// unsubscribed while subscribing
+		inner.Unsubscribe()
+		return nil
+	default:
+	}
+
+	go s.forward(conn, inner, ch)
+	return nil
+}
+
+func (s *reconnectingSubscription) resubscribe(conn *gethrpc.Client) error {
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	defer cancel()
+
+	return s.subscribe(ctx, conn)
+}
+
+func (s *reconnectingSubscription) forward(conn *gethrpc.Client, inner *gethrpc.ClientSubscription, ch reflect.Value) {
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(s.done)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(inner.Err())},
+		{Dir: reflect.SelectRecv, Chan: ch},
+	}
+	for {
+		chosen, recv, _ := reflect.Select(cases)
+		switch chosen {
+		case 0: // <-s.done
+			return
+		case 1: // <-inner.Err()
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+			// the server subscription ends only if the connection is lost or closed
+			s.lost(conn)
+			return
+		case 2: // <-ch
+			sendCases := []reflect.SelectCase{
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(s.done)},
+				{Dir: reflect.SelectSend, Chan: s.channel, Send: recv},
+			}
+			if chosen, _, _ := reflect.Select(sendCases); chosen == 0 {
+				return
+			}
+		}
+	}
+}
+
+// unsubscribe is called when the subscriber unsubscribes
+func (s *reconnectingSubscription) unsubscribe() {
+	s.remove()
+
+	s.stop()
+}
+
+// stop stops forwarding and unsubscribes the server subscription
+func (s *reconnectingSubscription) stop() {
+	s.doneOnce.Do(func() {
+		close(s.done)
+	})
+
+	s.mu.Lock()
+	inner := s.inner
+	s.mu.Unlock()
+
+	if inner != nil {
+		inner.Unsubscribe()
+	}
+}
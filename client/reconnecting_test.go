@@ -0,0 +1,232 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubNode is a websocket JSON-RPC server that serves system_name, system_health and a chain_subscribeNewHead
+// subscription sending increasing numbers, and can drop all connections to simulate a node restart
+type stubNode struct {
+	*httptest.Server
+	name string
+
+	mu      sync.Mutex
+	syncing bool
+	conns   map[*websocket.Conn]struct{}
+	subIDs  int
+}
+
+type stubRequest struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+func newStubNode() *stubNode {
+	n := &stubNode{name: "stub", conns: make(map[*websocket.Conn]struct{})}
+	n.Server = httptest.NewServer(http.HandlerFunc(n.serve))
+	return n
+}
+
+func (n *stubNode) URL() string {
+	return "ws" + strings.TrimPrefix(n.Server.URL, "http")
+}
+
+func (n *stubNode) serve(w http.ResponseWriter, r *http.Request) {
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	n.mu.Lock()
+	n.conns[conn] = struct{}{}
+	n.mu.Unlock()
+
+	var writeMu sync.Mutex
+	write := func(msg string) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		return conn.WriteMessage(websocket.TextMessage, []byte(msg))
+	}
+
+	for {
+		var req stubRequest
+		if err := conn.ReadJSON(&req); err != nil {
+			return
+		}
+
+		switch req.Method {
+		case "system_name":
+			_ = write(fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":"%v"}`, req.ID, n.name))
+		case "system_health":
+			n.mu.Lock()
+			syncing := n.syncing
+			n.mu.Unlock()
+			_ = write(fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":{"peers":3,"isSyncing":%v,`+
+				`"shouldHavePeers":true}}`, req.ID, syncing))
+		case "chain_subscribeNewHead":
+			n.mu.Lock()
+			n.subIDs++
+			subID := n.subIDs
+			n.mu.Unlock()
+
+			_ = write(fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":"%d"}`, req.ID, subID))
+			go func() {
+				for i := 0; ; i++ {
+					err := write(fmt.Sprintf(`{"jsonrpc":"2.0","method":"chain_newHead",`+
+						`"params":{"subscription":"%d","result":%d}}`, subID, i))
+					if err != nil {
+						return
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+			}()
+		default:
+			_ = write(fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":true}`, req.ID))
+		}
+	}
+}
+
+func (n *stubNode) setSyncing(syncing bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.syncing = syncing
+}
+
+func (n *stubNode) connections() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.conns)
+}
+
+func (n *stubNode) subscriptions() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.subIDs
+}
+
+func (n *stubNode) dropConnections() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for conn := range n.conns {
+		conn.Close()
+	}
+	n.conns = make(map[*websocket.Conn]struct{})
+}
+
+func TestReconnectingClient_Resubscribes(t *testing.T) {
+	node := newStubNode()
+	defer node.Close()
+
+	cl, err := ConnectWithReconnect(node.URL(), ReconnectOptions{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+	})
+	assert.NoError(t, err)
+	defer cl.Close()
+
+	ch := make(chan int)
+	sub, err := cl.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, <-ch)
+
+	node.dropConnections()
+
+	select {
+	case event := <-cl.Reconnects():
+		assert.Equal(t, 1, event.Attempts)
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("client did not reconnect within 5s")
+	}
+
+	// notifications of the new server subscription arrive on the same channel, starting at 0 again
+	for v := <-ch; v != 0; v = <-ch {
+	}
+	assert.Equal(t, 1, <-ch)
+
+	var name string
+	err = cl.Call(&name, "system_name")
+	assert.NoError(t, err)
+	assert.Equal(t, "stub", name)
+
+	sub.Unsubscribe()
+	_, ok := <-sub.Err()
+	assert.False(t, ok)
+}
+
+func TestReconnectingClient_GivesUp(t *testing.T) {
+	node := newStubNode()
+
+	cl, err := ConnectWithReconnect(node.URL(), ReconnectOptions{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+		MaxAttempts:    2,
+	})
+	assert.NoError(t, err)
+	defer cl.Close()
+
+	ch := make(chan int, 100)
+	sub, err := cl.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	assert.NoError(t, err)
+
+	node.dropConnections()
+	node.Close()
+
+	select {
+	case err := <-sub.Err():
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "after 2 attempts")
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription did not fail within 5s")
+	}
+}
+
+func TestReconnectingClient_Close(t *testing.T) {
+	node := newStubNode()
+	defer node.Close()
+
+	cl, err := ConnectWithReconnect(node.URL(), DefaultReconnectOptions())
+	assert.NoError(t, err)
+
+	ch := make(chan int, 100)
+	sub, err := cl.Subscribe(context.Background(), "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
+	assert.NoError(t, err)
+
+	cl.Close()
+
+	select {
+	case err := <-sub.Err():
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription did not end within 5s")
+	}
+}
@@ -0,0 +1,109 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Errors returned by VerifySignedCommitment, wrapped with details on the offending signature
+var (
+	ErrValidatorSetMismatch   = errors.New("commitment is signed by another validator set")
+	ErrSignatureCountMismatch = errors.New("number of signatures does not match the number of validators")
+	ErrInvalidBeefySignature  = errors.New("invalid beefy signature")
+	ErrInsufficientSignatures = errors.New("signatures do not reach the threshold of the validator set")
+)
+
+// BeefyValidatorSet is a set of BEEFY validators identified by its id, e.g. from Beefy.ValidatorSetId and
+// Beefy.Authorities. The order of the validators matters, since signed commitments list signatures by position.
+type BeefyValidatorSet struct {
+	ID         uint64
+	Validators []types.BeefyAuthorityID
+}
+
+// Threshold returns the number of signatures needed for a commitment to be valid, which is more than 2/3 of the
+// validators
+func (s BeefyValidatorSet) Threshold() int {
+	n := len(s.Validators)
+	if n == 0 {
+		return 0
+	}
+	return n - (n-1)/3
+}
+
+// VerifySignedCommitment verifies that the commitment has been signed by the validator set s. The public key of every
+// present signature is recovered from the keccak256 hash of the encoded commitment and must be the validator at the
+// position of the signature. The number of valid signatures must reach the threshold of the set. On failure, the
+// returned error wraps one of the Err values of this package.
+func (s BeefyValidatorSet) VerifySignedCommitment(c types.SignedCommitment) error {
+	if uint64(c.Commitment.ValidatorSetID) != s.ID {
+		return fmt.Errorf("%w: got set %v, expected set %v", ErrValidatorSetMismatch, c.Commitment.ValidatorSetID,
+			s.ID)
+	}
+	if len(c.Signatures) != len(s.Validators) {
+		return fmt.Errorf("%w: got %v signatures for %v validators", ErrSignatureCountMismatch, len(c.Signatures),
+			len(s.Validators))
+	}
+
+	enc, err := types.EncodeToBytes(c.Commitment)
+	if err != nil {
+		return err
+	}
+	hash := crypto.Keccak256(enc)
+
+	signed := 0
+	for i, sig := range c.Signatures {
+		ok, value := sig.Unwrap()
+		if !ok {
+			continue
+		}
+
+		pub, err := recoverBeefySigner(hash, value)
+		if err != nil {
+			return fmt.Errorf("%w: signature %v: %v", ErrInvalidBeefySignature, i, err)
+		}
+		if !bytes.Equal(pub, s.Validators[i][:]) {
+			return fmt.Errorf("%w: signature %v is not signed by validator %#x", ErrInvalidBeefySignature, i,
+				s.Validators[i])
+		}
+		signed++
+	}
+
+	if signed < s.Threshold() {
+		return fmt.Errorf("%w: got %v, need %v of %v", ErrInsufficientSignatures, signed, s.Threshold(),
+			len(s.Validators))
+	}
+	return nil
+}
+
+// recoverBeefySigner recovers the compressed public key that signed hash, accepting recovery ids of 0 and 1 as well as
+// the Ethereum style 27 and 28
+func recoverBeefySigner(hash []byte, sig types.BeefySignature) ([]byte, error) {
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash, sig[:])
+	if err != nil {
+		return nil, err
+	}
+	return crypto.CompressPubkey(pub), nil
+}
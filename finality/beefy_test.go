@@ -0,0 +1,108 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBeefyValidators(t *testing.T, n int) ([]*ecdsa.PrivateKey, BeefyValidatorSet) {
+	keys := make([]*ecdsa.PrivateKey, n)
+	set := BeefyValidatorSet{ID: 2, Validators: make([]types.BeefyAuthorityID, n)}
+	for i := range keys {
+		seed := make([]byte, 32)
+		seed[31] = byte(i + 1)
+		key, err := crypto.ToECDSA(seed)
+		assert.NoError(t, err)
+		keys[i] = key
+		copy(set.Validators[i][:], crypto.CompressPubkey(&key.PublicKey))
+	}
+	return keys, set
+}
+
+// signCommitment signs the commitment with the keys of the given validators, leaving the other signatures empty
+func signCommitment(t *testing.T, c types.Commitment, keys []*ecdsa.PrivateKey, signers ...int) types.SignedCommitment {
+	enc, err := types.EncodeToBytes(c)
+	assert.NoError(t, err)
+	hash := crypto.Keccak256(enc)
+
+	sc := types.SignedCommitment{Commitment: c, Signatures: make([]types.OptionBeefySignature, len(keys))}
+	for i := range sc.Signatures {
+		sc.Signatures[i] = types.NewOptionBeefySignatureEmpty()
+	}
+	for _, i := range signers {
+		sig, err := crypto.Sign(hash, keys[i])
+		assert.NoError(t, err)
+		var bs types.BeefySignature
+		copy(bs[:], sig)
+		sc.Signatures[i] = types.NewOptionBeefySignature(bs)
+	}
+	return sc
+}
+
+func TestBeefyValidatorSet_VerifySignedCommitment(t *testing.T) {
+	keys, set := newTestBeefyValidators(t, 4)
+	c := types.Commitment{Payload: types.H256{1, 2, 3}, BlockNumber: 100, ValidatorSetID: 2}
+
+	assert.NoError(t, set.VerifySignedCommitment(signCommitment(t, c, keys, 0, 1, 2, 3)))
+	assert.NoError(t, set.VerifySignedCommitment(signCommitment(t, c, keys, 0, 2, 3)))
+
+	// Ethereum style recovery ids
+	sc := signCommitment(t, c, keys, 0, 1, 2)
+	for i := 0; i < 3; i++ {
+		_, sig := sc.Signatures[i].Unwrap()
+		sig[64] += 27
+		sc.Signatures[i].SetSome(sig)
+	}
+	assert.NoError(t, set.VerifySignedCommitment(sc))
+}
+
+func TestBeefyValidatorSet_VerifySignedCommitment_Errors(t *testing.T) {
+	keys, set := newTestBeefyValidators(t, 4)
+	c := types.Commitment{Payload: types.H256{1, 2, 3}, BlockNumber: 100, ValidatorSetID: 2}
+
+	err := set.VerifySignedCommitment(signCommitment(t, c, keys, 0, 1))
+	assert.ErrorIs(t, err, ErrInsufficientSignatures)
+	assert.EqualError(t, err, "signatures do not reach the threshold of the validator set: got 2, need 3 of 4")
+
+	other := c
+	other.ValidatorSetID = 3
+	err = set.VerifySignedCommitment(signCommitment(t, other, keys, 0, 1, 2))
+	assert.ErrorIs(t, err, ErrValidatorSetMismatch)
+
+	sc := signCommitment(t, c, keys, 0, 1, 2)
+	sc.Signatures = sc.Signatures[:3]
+	err = set.VerifySignedCommitment(sc)
+	assert.ErrorIs(t, err, ErrSignatureCountMismatch)
+
+	// signatures in the wrong position
+	sc = signCommitment(t, c, keys, 0, 1, 2)
+	sc.Signatures[0], sc.Signatures[3] = sc.Signatures[3], sc.Signatures[0]
+	err = set.VerifySignedCommitment(sc)
+	assert.ErrorIs(t, err, ErrInvalidBeefySignature)
+
+	// signed for another block
+	sc = signCommitment(t, c, keys, 0, 1, 2)
+	sc.Commitment.BlockNumber = 101
+	err = set.VerifySignedCommitment(sc)
+	assert.ErrorIs(t, err, ErrInvalidBeefySignature)
+}
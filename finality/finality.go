@@ -0,0 +1,126 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package finality verifies GRANDPA justifications offline against a known authority set, which is what a light
+// client or bridge relayer needs to trust a finalized block without trusting the node serving it:
+//
+//	set, err := finality.NewAuthoritySet(setID, authorities)
+//	proof, ok, err := api.RPC.Grandpa.ProveFinality(number)
+//	justification, err := proof.DecodeJustification()
+//	err = set.VerifyJustification(proof.Block, uint32(number), justification)
+//
+// The authorities are decoded from the Grandpa.Authorities storage, or taken from the scheduled changes of the
+// consensus digests of headers with NewAuthoritySetFromDigest to follow the authority set across changes.
+//
+// BEEFY signed commitments are verified likewise with BeefyValidatorSet.VerifySignedCommitment. The MMR leaves of
+// blocks, as returned with their proof by api.RPC.Mmr.GenerateProof, are then verified against the MMR root of a
+// verified commitment with VerifyMmrLeafProof.
+package finality
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// Errors returned by VerifyJustification, wrapped with details on the offending precommit
+var (
+	ErrTargetMismatch      = errors.New("justification does not finalize the given block")
+	ErrUnknownAuthority    = errors.New("precommit by an authority not in the set")
+	ErrInvalidSignature    = errors.New("invalid precommit signature")
+	ErrInvalidAncestry     = errors.New("precommit target is not a descendant of the commit target")
+	ErrUnusedAncestry      = errors.New("votes ancestries contain headers not needed by any precommit")
+	ErrInsufficientWeight  = errors.New("precommits do not reach the threshold weight of the authority set")
+	ErrInvalidAuthoritySet = errors.New("invalid authority set")
+)
+
+// AuthoritySet is a set of GRANDPA authorities with their weights, identified by its set id, which is incremented on
+// every change of the set
+type AuthoritySet struct {
+	SetID       uint64
+	Authorities []types.GrandpaAuthority
+	weights     map[types.AuthorityID]uint64
+	total       uint64
+}
+
+// NewAuthoritySet creates the authority set with the given id, e.g. from Grandpa.CurrentSetId and Grandpa.Authorities
+func NewAuthoritySet(setID uint64, authorities []types.GrandpaAuthority) (AuthoritySet, error) {
+	if len(authorities) == 0 {
+		return AuthoritySet{}, fmt.Errorf("%w: no authorities", ErrInvalidAuthoritySet)
+	}
+
+	weights := make(map[types.AuthorityID]uint64, len(authorities))
+	var total uint64
+	for _, a := range authorities {
+		if a.Weight == 0 {
+			return AuthoritySet{}, fmt.Errorf("%w: authority %#x has no weight", ErrInvalidAuthoritySet, a.ID)
+		}
+		if _, ok := weights[a.ID]; ok {
+			return AuthoritySet{}, fmt.Errorf("%w: duplicate authority %#x", ErrInvalidAuthoritySet, a.ID)
+		}
+		if total+uint64(a.Weight) < total {
+			return AuthoritySet{}, fmt.Errorf("%w: total weight overflows", ErrInvalidAuthoritySet)
+		}
+		weights[a.ID] = uint64(a.Weight)
+		total += uint64(a.Weight)
+	}
+
+	return AuthoritySet{SetID: setID, Authorities: authorities, weights: weights, total: total}, nil
+}
+
+// NewAuthoritySetFromDigest returns the authority set scheduled or forced by a GRANDPA consensus log in the given
+// digest of a header. The returned set gets the id setID, which is the id of the set after the change is enacted,
+// i.e. one more than the id of the set at the time of the header. Ok is false if the digest does not change the set.
+func NewAuthoritySetFromDigest(setID uint64, digest types.Digest) (set AuthoritySet, ok bool, err error) {
+	for _, item := range digest {
+		if !item.IsConsensus || item.AsConsensus.ConsensusEngineID != types.GrandpaEngineID {
+			continue
+		}
+
+		var log types.GrandpaConsensusLog
+		err = types.DecodeFromBytes(item.AsConsensus.Bytes, &log)
+		if err != nil {
+			return AuthoritySet{}, false, fmt.Errorf("unable to decode GRANDPA consensus log: %v", err)
+		}
+
+		switch {
+		case log.IsScheduledChange:
+			set, err = NewAuthoritySet(setID, log.AsScheduledChange.NextAuthorities)
+		case log.IsForcedChange:
+			set, err = NewAuthoritySet(setID, log.AsForcedChange.Change.NextAuthorities)
+		default:
+			continue
+		}
+		return set, err == nil, err
+	}
+	return AuthoritySet{}, false, nil
+}
+
+// TotalWeight returns the sum of the weights of all authorities
+func (s AuthoritySet) TotalWeight() uint64 {
+	return s.total
+}
+
+// Threshold returns the weight of precommits needed to finalize a block, which is more than 2/3 of the total weight,
+// or the total weight minus the weight that may be faulty
+func (s AuthoritySet) Threshold() uint64 {
+	if s.total == 0 {
+		return 0
+	}
+	faulty := (s.total - 1) / 3
+	return s.total - faulty
+}
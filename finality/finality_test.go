@@ -0,0 +1,104 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// testAuthority is an ed25519 key pair of a GRANDPA authority used to sign precommits in tests
+type testAuthority struct {
+	id  types.AuthorityID
+	key ed25519.PrivateKey
+}
+
+func newTestAuthorities(n int) []testAuthority {
+	authorities := make([]testAuthority, n)
+	for i := range authorities {
+		seed := make([]byte, ed25519.SeedSize)
+		seed[0] = byte(i + 1)
+		key := ed25519.NewKeyFromSeed(seed)
+		copy(authorities[i].id[:], key.Public().(ed25519.PublicKey))
+		authorities[i].key = key
+	}
+	return authorities
+}
+
+func newTestAuthoritySet(t *testing.T, setID uint64, authorities []testAuthority) AuthoritySet {
+	list := make([]types.GrandpaAuthority, len(authorities))
+	for i, a := range authorities {
+		list[i] = types.GrandpaAuthority{ID: a.id, Weight: 1}
+	}
+	set, err := NewAuthoritySet(setID, list)
+	assert.NoError(t, err)
+	return set
+}
+
+func TestNewAuthoritySet(t *testing.T) {
+	authorities := newTestAuthorities(4)
+	set := newTestAuthoritySet(t, 7, authorities)
+	assert.Equal(t, uint64(7), set.SetID)
+	assert.Equal(t, uint64(4), set.TotalWeight())
+	assert.Equal(t, uint64(3), set.Threshold())
+
+	_, err := NewAuthoritySet(0, nil)
+	assert.EqualError(t, err, "invalid authority set: no authorities")
+
+	a := types.GrandpaAuthority{ID: authorities[0].id, Weight: 1}
+	_, err = NewAuthoritySet(0, []types.GrandpaAuthority{a, a})
+	assert.ErrorIs(t, err, ErrInvalidAuthoritySet)
+
+	_, err = NewAuthoritySet(0, []types.GrandpaAuthority{{ID: a.ID}})
+	assert.ErrorIs(t, err, ErrInvalidAuthoritySet)
+}
+
+func TestAuthoritySet_Threshold(t *testing.T) {
+	for total, threshold := range map[uint64]uint64{1: 1, 2: 2, 3: 3, 4: 3, 5: 4, 6: 5, 7: 5, 10: 7, 100: 67} {
+		set := AuthoritySet{total: total}
+		assert.Equal(t, threshold, set.Threshold(), "total weight %v", total)
+	}
+}
+
+func TestNewAuthoritySetFromDigest(t *testing.T) {
+	authorities := newTestAuthorities(2)
+	next := []types.GrandpaAuthority{{ID: authorities[0].id, Weight: 1}, {ID: authorities[1].id, Weight: 2}}
+
+	change, err := types.EncodeToBytes(types.GrandpaConsensusLog{
+		IsScheduledChange: true,
+		AsScheduledChange: types.GrandpaScheduledChange{NextAuthorities: next, Delay: 0},
+	})
+	assert.NoError(t, err)
+	digest := types.Digest{
+		{IsPreRuntime: true, AsPreRuntime: types.PreRuntime{ConsensusEngineID: 0x45424142, Bytes: types.Bytes{1}}},
+		{IsConsensus: true, AsConsensus: types.Consensus{ConsensusEngineID: types.GrandpaEngineID, Bytes: change}},
+	}
+
+	set, ok, err := NewAuthoritySetFromDigest(8, digest)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(8), set.SetID)
+	assert.Equal(t, next, set.Authorities)
+	assert.Equal(t, uint64(3), set.TotalWeight())
+
+	_, ok, err = NewAuthoritySetFromDigest(8, digest[:1])
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
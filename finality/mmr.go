@@ -0,0 +1,311 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Errors returned when verifying MMR proofs
+var (
+	ErrCorruptedMmrProof = errors.New("corrupted mmr proof")
+	ErrMmrRootMismatch   = errors.New("mmr root does not match the commitment")
+)
+
+// VerifyMmrLeafProof verifies that leaf is part of the MMR whose root is the payload of the BEEFY commitment c, e.g. of
+// a signed commitment verified with BeefyValidatorSet.VerifySignedCommitment
+func VerifyMmrLeafProof(c types.Commitment, leaf types.MmrLeaf, proof types.MmrProof) error {
+	root, err := MmrRoot(leaf, proof)
+	if err != nil {
+		return err
+	}
+	if root != c.Payload {
+		return fmt.Errorf("%w: got root %#x, expected %#x", ErrMmrRootMismatch, root, c.Payload)
+	}
+	return nil
+}
+
+// VerifyMmrBatchProof is like VerifyMmrLeafProof for several leaves proven at once
+func VerifyMmrBatchProof(c types.Commitment, leaves []types.MmrLeaf, proof types.MmrBatchProof) error {
+	root, err := MmrBatchRoot(leaves, proof)
+	if err != nil {
+		return err
+	}
+	if root != c.Payload {
+		return fmt.Errorf("%w: got root %#x, expected %#x", ErrMmrRootMismatch, root, c.Payload)
+	}
+	return nil
+}
+
+// MmrRoot recomputes the root of the MMR from a leaf and its proof, hashing nodes with keccak256
+func MmrRoot(leaf types.MmrLeaf, proof types.MmrProof) (types.H256, error) {
+	return MmrBatchRoot([]types.MmrLeaf{leaf}, types.MmrBatchProof{
+		LeafIndices: []types.U64{proof.LeafIndex},
+		LeafCount:   proof.LeafCount,
+		Items:       proof.Items,
+	})
+}
+
+// MmrBatchRoot recomputes the root of the MMR from the leaves and their proof, hashing nodes with keccak256. The leaves
+// must be in the order of proof.LeafIndices.
+func MmrBatchRoot(leaves []types.MmrLeaf, proof types.MmrBatchProof) (types.H256, error) {
+	if len(leaves) == 0 || len(leaves) != len(proof.LeafIndices) {
+		return types.H256{}, fmt.Errorf("%w: got %v leaves for %v leaf indices", ErrCorruptedMmrProof, len(leaves),
+			len(proof.LeafIndices))
+	}
+	if uint64(proof.LeafCount) > mmrMaxLeafCount {
+		return types.H256{}, fmt.Errorf("%w: leaf count %v exceeds %v", ErrCorruptedMmrProof, proof.LeafCount,
+			uint64(mmrMaxLeafCount))
+	}
+
+	nodes := make([]mmrNode, len(leaves))
+	for i, leaf := range leaves {
+		index := uint64(proof.LeafIndices[i])
+		if index >= uint64(proof.LeafCount) {
+			return types.H256{}, fmt.Errorf("%w: leaf index %v out of range for %v leaves", ErrCorruptedMmrProof,
+				index, proof.LeafCount)
+		}
+		enc, err := types.EncodeToBytes(leaf)
+		if err != nil {
+			return types.H256{}, err
+		}
+		nodes[i] = mmrNode{pos: mmrLeafIndexToPos(index), hash: keccakH256(enc)}
+	}
+
+	peaks, err := mmrPeakHashes(nodes, mmrSize(uint64(proof.LeafCount)), proof.Items)
+	if err != nil {
+		return types.H256{}, err
+	}
+	return mmrBagPeaks(peaks), nil
+}
+
+// mmrNode is a node of the MMR with its position in the MMR, i.e. its index in the post-order of all nodes
+type mmrNode struct {
+	pos    uint64
+	hash   types.H256
+	height uint32
+}
+
+// mmrProofItems iterates over the items of a proof
+type mmrProofItems struct {
+	items []types.H256
+}
+
+func (p *mmrProofItems) next() (types.H256, bool) {
+	if len(p.items) == 0 {
+		return types.H256{}, false
+	}
+	item := p.items[0]
+	p.items = p.items[1:]
+	return item, true
+}
+
+// mmrPeakHashes computes the hashes of the peaks of an MMR of the given size from the proven nodes and the proof
+// items. The peaks to the right of the proven nodes are expected to be already bagged into a single proof item.
+func mmrPeakHashes(nodes []mmrNode, size uint64, items []types.H256) ([]types.H256, error) {
+	proof := &mmrProofItems{items}
+
+	// a single leaf is its own root
+	if size == 1 && len(nodes) == 1 && nodes[0].pos == 0 {
+		if len(items) != 0 {
+			return nil, fmt.Errorf("%w: %v proof items left", ErrCorruptedMmrProof, len(items))
+		}
+		return []types.H256{nodes[0].hash}, nil
+	}
+
+	sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].pos < nodes[j].pos })
+
+	var peaks []types.H256
+loop:
+	for _, peakPos := range mmrPeaks(size) {
+		n := 0
+		for n < len(nodes) && nodes[n].pos <= peakPos {
+			n++
+		}
+		under := nodes[:n:n]
+		nodes = nodes[n:]
+
+		switch {
+		case len(under) == 1 && under[0].pos == peakPos:
+			peaks = append(peaks, under[0].hash)
+		case len(under) == 0:
+			// the peak or the bagged peaks to its right are part of the proof, if none is left all peaks to the
+			// right have been bagged already
+			peak, ok := proof.next()
+			if !ok {
+				break loop
+			}
+			peaks = append(peaks, peak)
+		default:
+			peak, err := mmrPeakRoot(under, peakPos, proof)
+			if err != nil {
+				return nil, err
+			}
+			peaks = append(peaks, peak)
+		}
+	}
+
+	if len(nodes) != 0 {
+		return nil, fmt.Errorf("%w: %v leaves outside of the mmr", ErrCorruptedMmrProof, len(nodes))
+	}
+	if peak, ok := proof.next(); ok {
+		peaks = append(peaks, peak)
+	}
+	if len(proof.items) != 0 {
+		return nil, fmt.Errorf("%w: %v proof items left", ErrCorruptedMmrProof, len(proof.items))
+	}
+	return peaks, nil
+}
+
+// mmrPeakRoot computes the hash of the peak at peakPos from the nodes below it, taking the siblings that are not
+// among the nodes from the proof
+func mmrPeakRoot(queue []mmrNode, peakPos uint64, proof *mmrProofItems) (types.H256, error) {
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node.pos == peakPos {
+			return node.hash, nil
+		}
+
+		var siblingPos, parentPos uint64
+		isRight := mmrPosHeight(node.pos+1) > node.height
+		if isRight {
+			siblingPos = node.pos - mmrSiblingOffset(node.height)
+			parentPos = node.pos + 1
+		} else {
+			siblingPos = node.pos + mmrSiblingOffset(node.height)
+			parentPos = node.pos + mmrParentOffset(node.height)
+		}
+
+		var sibling types.H256
+		if len(queue) > 0 && queue[0].pos == siblingPos {
+			sibling = queue[0].hash
+			queue = queue[1:]
+		} else {
+			var ok bool
+			sibling, ok = proof.next()
+			if !ok {
+				return types.H256{}, fmt.Errorf("%w: missing sibling of node %v", ErrCorruptedMmrProof, node.pos)
+			}
+		}
+
+		var parent types.H256
+		if isRight {
+			parent = mmrMerge(sibling, node.hash)
+		} else {
+			parent = mmrMerge(node.hash, sibling)
+		}
+		if parentPos >= peakPos {
+			return parent, nil
+		}
+		queue = append(queue, mmrNode{pos: parentPos, hash: parent, height: node.height + 1})
+	}
+	return types.H256{}, fmt.Errorf("%w: no node below peak %v", ErrCorruptedMmrProof, peakPos)
+}
+
+// mmrBagPeaks bags the peaks from right to left into the root of the MMR, hashing each bag with the peak to its left
+func mmrBagPeaks(peaks []types.H256) types.H256 {
+	bag := peaks[len(peaks)-1]
+	for i := len(peaks) - 2; i >= 0; i-- {
+		bag = mmrMerge(bag, peaks[i])
+	}
+	return bag
+}
+
+func mmrMerge(left, right types.H256) types.H256 {
+	return keccakH256(left[:], right[:])
+}
+
+func keccakH256(data ...[]byte) types.H256 {
+	var h types.H256
+	copy(h[:], crypto.Keccak256(data...))
+	return h
+}
+
+// mmrMaxLeafCount is the maximum number of leaves of an MMR whose size fits into an uint64
+const mmrMaxLeafCount = 1 << 62
+
+// mmrSize returns the number of nodes of an MMR with the given number of leaves
+func mmrSize(leafCount uint64) uint64 {
+	return 2*leafCount - uint64(bits.OnesCount64(leafCount))
+}
+
+// mmrLeafIndexToPos returns the position of the leaf with the given index
+func mmrLeafIndexToPos(index uint64) uint64 {
+	return mmrSize(index+1) - uint64(bits.TrailingZeros64(index+1)) - 1
+}
+
+// mmrPosHeight returns the height of the node at pos, with leaves being at height 0
+func mmrPosHeight(pos uint64) uint32 {
+	pos++
+	// jump left in the tree until pos is the rightmost node of a perfect tree, i.e. all ones
+	for bits.OnesCount64(pos) != bits.Len64(pos) {
+		pos -= 1<<(bits.Len64(pos)-1) - 1
+	}
+	return uint32(bits.Len64(pos) - 1)
+}
+
+func mmrParentOffset(height uint32) uint64 {
+	return 2 << height
+}
+
+func mmrSiblingOffset(height uint32) uint64 {
+	return 2<<height - 1
+}
+
+// mmrPeaks returns the positions of the peaks of an MMR of the given size, from left to right
+func mmrPeaks(size uint64) []uint64 {
+	if size == 0 {
+		return nil
+	}
+
+	// the leftmost peak is the highest perfect tree fitting into the MMR. A tree of height 63 takes all positions of an
+	// uint64, so there are neither higher peaks nor peaks to its right.
+	height := uint32(0)
+	for height < 63 && peakPosByHeight(height+1) < size {
+		height++
+	}
+	pos := peakPosByHeight(height)
+	peaks := []uint64{pos}
+	if height == 63 {
+		return peaks
+	}
+
+	for height > 0 {
+		// move to the right sibling, then down to the left child until the node is within the MMR
+		pos += mmrSiblingOffset(height)
+		for pos > size-1 {
+			if height == 0 {
+				return peaks
+			}
+			pos -= mmrParentOffset(height - 1)
+			height--
+		}
+		peaks = append(peaks, pos)
+	}
+	return peaks
+}
+
+func peakPosByHeight(height uint32) uint64 {
+	return 1<<(height+1) - 2
+}
@@ -0,0 +1,185 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"math"
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// testMmr is an MMR of 7 leaves built by hand, with the nodes at positions 0 to 10:
+//
+//	      6
+//	  2       5       9
+//	0   1   3   4   7   8   10
+type testMmr struct {
+	leaves []types.MmrLeaf
+	// leafHashes are the hashes of the leaves 0 to 6, at positions 0, 1, 3, 4, 7, 8 and 10
+	leafHashes       []types.H256
+	n2, n5, n6, n9   types.H256
+	rightPeaks, root types.H256
+	commitment       types.Commitment
+}
+
+func hashPair(left, right types.H256) types.H256 {
+	var h types.H256
+	copy(h[:], crypto.Keccak256(left[:], right[:]))
+	return h
+}
+
+func newTestMmr(t *testing.T) testMmr {
+	var m testMmr
+	for i := 0; i < 7; i++ {
+		leaf := types.MmrLeaf{
+			ParentNumberAndHash:   types.ParentNumberAndHash{ParentNumber: types.U32(i), Hash: types.Hash{byte(i)}},
+			BeefyNextAuthoritySet: types.BeefyNextAuthoritySet{ID: 1, Len: 4, Root: types.H256{1}},
+		}
+		enc, err := types.EncodeToBytes(leaf)
+		assert.NoError(t, err)
+		var h types.H256
+		copy(h[:], crypto.Keccak256(enc))
+		m.leaves = append(m.leaves, leaf)
+		m.leafHashes = append(m.leafHashes, h)
+	}
+
+	h := m.leafHashes
+	m.n2 = hashPair(h[0], h[1])
+	m.n5 = hashPair(h[2], h[3])
+	m.n6 = hashPair(m.n2, m.n5)
+	m.n9 = hashPair(h[4], h[5])
+	// peaks are bagged from right to left, hashing the right bag first
+	m.rightPeaks = hashPair(h[6], m.n9)
+	m.root = hashPair(m.rightPeaks, m.n6)
+	m.commitment = types.Commitment{Payload: m.root, BlockNumber: 8, ValidatorSetID: 1}
+	return m
+}
+
+func TestMmrRoot(t *testing.T) {
+	m := newTestMmr(t)
+	h := m.leafHashes
+
+	for _, test := range []struct {
+		index uint64
+		items []types.H256
+	}{
+		{0, []types.H256{h[1], m.n5, m.rightPeaks}},
+		{3, []types.H256{h[2], m.n2, m.rightPeaks}},
+		{5, []types.H256{m.n6, h[4], h[6]}},
+		{6, []types.H256{m.n6, m.n9}},
+	} {
+		proof := types.MmrProof{LeafIndex: types.U64(test.index), LeafCount: 7, Items: test.items}
+		root, err := MmrRoot(m.leaves[test.index], proof)
+		assert.NoError(t, err)
+		assert.Equal(t, m.root, root, "leaf %v", test.index)
+		assert.NoError(t, VerifyMmrLeafProof(m.commitment, m.leaves[test.index], proof))
+	}
+}
+
+func TestMmrRoot_SmallMmrs(t *testing.T) {
+	m := newTestMmr(t)
+	h := m.leafHashes
+
+	root, err := MmrRoot(m.leaves[0], types.MmrProof{LeafIndex: 0, LeafCount: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, h[0], root)
+
+	root, err = MmrRoot(m.leaves[1], types.MmrProof{LeafIndex: 1, LeafCount: 2, Items: []types.H256{h[0]}})
+	assert.NoError(t, err)
+	assert.Equal(t, m.n2, root)
+
+	root, err = MmrRoot(m.leaves[2], types.MmrProof{LeafIndex: 2, LeafCount: 3, Items: []types.H256{m.n2}})
+	assert.NoError(t, err)
+	assert.Equal(t, hashPair(h[2], m.n2), root)
+}
+
+func TestMmrBatchRoot(t *testing.T) {
+	m := newTestMmr(t)
+	h := m.leafHashes
+
+	proof := types.MmrBatchProof{
+		LeafIndices: []types.U64{1, 4},
+		LeafCount:   7,
+		Items:       []types.H256{h[0], m.n5, h[5], h[6]},
+	}
+	root, err := MmrBatchRoot([]types.MmrLeaf{m.leaves[1], m.leaves[4]}, proof)
+	assert.NoError(t, err)
+	assert.Equal(t, m.root, root)
+
+	// leaves given in another order than their positions
+	proof = types.MmrBatchProof{
+		LeafIndices: []types.U64{3, 0, 2},
+		LeafCount:   7,
+		Items:       []types.H256{h[1], m.rightPeaks},
+	}
+	err = VerifyMmrBatchProof(m.commitment, []types.MmrLeaf{m.leaves[3], m.leaves[0], m.leaves[2]}, proof)
+	assert.NoError(t, err)
+}
+
+func TestVerifyMmrLeafProof_Errors(t *testing.T) {
+	m := newTestMmr(t)
+	h := m.leafHashes
+
+	proof := types.MmrProof{LeafIndex: 0, LeafCount: 7, Items: []types.H256{h[1], m.n5, m.rightPeaks}}
+	err := VerifyMmrLeafProof(m.commitment, m.leaves[1], proof)
+	assert.ErrorIs(t, err, ErrMmrRootMismatch)
+
+	other := m.commitment
+	other.Payload = types.H256{1}
+	err = VerifyMmrLeafProof(other, m.leaves[0], proof)
+	assert.ErrorIs(t, err, ErrMmrRootMismatch)
+
+	// a single item too many is taken for the bagged peaks to the right of the leaf
+	proof.Items = append(proof.Items, h[2])
+	err = VerifyMmrLeafProof(m.commitment, m.leaves[0], proof)
+	assert.ErrorIs(t, err, ErrMmrRootMismatch)
+
+	proof = types.MmrProof{LeafIndex: 6, LeafCount: 7, Items: []types.H256{m.n6, m.n9, h[1], h[2]}}
+	err = VerifyMmrLeafProof(m.commitment, m.leaves[6], proof)
+	assert.EqualError(t, err, "corrupted mmr proof: 1 proof items left")
+
+	proof = types.MmrProof{LeafIndex: 0, LeafCount: 7}
+
+	proof.Items = []types.H256{h[1]}
+	err = VerifyMmrLeafProof(m.commitment, m.leaves[0], proof)
+	assert.ErrorIs(t, err, ErrCorruptedMmrProof)
+
+	proof = types.MmrProof{LeafIndex: 7, LeafCount: 7, Items: []types.H256{m.n6, m.n9}}
+	err = VerifyMmrLeafProof(m.commitment, m.leaves[6], proof)
+	assert.ErrorIs(t, err, ErrCorruptedMmrProof)
+
+	err = VerifyMmrBatchProof(m.commitment, nil, types.MmrBatchProof{LeafCount: 7})
+	assert.ErrorIs(t, err, ErrCorruptedMmrProof)
+
+	// leaf counts whose mmr size overflows are rejected instead of looping forever
+	proof = types.MmrProof{LeafIndex: 0, LeafCount: 1 << 63, Items: []types.H256{h[1], m.n5, m.rightPeaks}}
+	err = VerifyMmrLeafProof(m.commitment, m.leaves[0], proof)
+	assert.EqualError(t, err, "corrupted mmr proof: leaf count 9223372036854775808 exceeds 4611686018427387904")
+
+	proof.LeafCount = 1 << 62
+	err = VerifyMmrLeafProof(m.commitment, m.leaves[0], proof)
+	assert.ErrorIs(t, err, ErrCorruptedMmrProof)
+}
+
+func TestMmrPeaks_MaxSize(t *testing.T) {
+	peaks := mmrPeaks(math.MaxUint64)
+	assert.Equal(t, uint64(1<<64-2), peaks[0])
+	assert.Len(t, peaks, 1)
+}
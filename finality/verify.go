@@ -0,0 +1,148 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"fmt"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/signature"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// precommitMessage is the message signed by an authority for a precommit, the encoded tuple of the precommit as
+// variant 1 of the GRANDPA message enum, the round and the set id
+type precommitMessage struct {
+	Variant   types.U8
+	Precommit types.GrandpaPrecommit
+	Round     types.U64
+	SetID     types.U64
+}
+
+// PrecommitSigningPayload returns the payload an authority of the set with the given id signs for a precommit in the
+// given round
+func PrecommitSigningPayload(precommit types.GrandpaPrecommit, round, setID uint64) ([]byte, error) {
+	return types.EncodeToBytes(precommitMessage{
+		Variant:   1,
+		Precommit: precommit,
+		Round:     types.U64(round),
+		SetID:     types.U64(setID),
+	})
+}
+
+// VerifyJustification verifies that the justification finalizes the block with the given hash and number for the
+// authority set s. It checks that the commit targets the block, that every precommit is signed by an authority of the
+// set and votes for the target or one of its descendants shown by the votes ancestries, that no ancestry is unused and
+// that the weight of the precommits reaches the threshold of the set. Like in GRANDPA, authorities equivocating with
+// several precommits in the round are accepted, each of their precommits is checked but their weight counts once. On
+// failure, the returned error wraps one of the Err values of this package.
+func (s AuthoritySet) VerifyJustification(hash types.Hash, number uint32, j types.GrandpaJustification) error {
+	commit := j.Commit
+	if commit.TargetHash != hash || uint32(commit.TargetNumber) != number {
+		return fmt.Errorf("%w: commit targets block %v (%#x), expected block %v (%#x)", ErrTargetMismatch,
+			commit.TargetNumber, commit.TargetHash, number, hash)
+	}
+
+	ancestry, err := newAncestryChain(j.VotesAncestries)
+	if err != nil {
+		return err
+	}
+
+	voted := make(map[types.AuthorityID]bool, len(commit.Precommits))
+	var weight uint64
+	for i, signed := range commit.Precommits {
+		authWeight, ok := s.weights[signed.ID]
+		if !ok {
+			return fmt.Errorf("%w: precommit %v by %#x", ErrUnknownAuthority, i, signed.ID)
+		}
+
+		payload, err := PrecommitSigningPayload(signed.Precommit, uint64(j.Round), s.SetID)
+		if err != nil {
+			return err
+		}
+		ok, err = signature.VerifyWithPublicKey(payload, signed.Signature[:], signed.ID[:],
+			signature.SignatureTypeEd25519)
+		if err != nil || !ok {
+			return fmt.Errorf("%w: precommit %v by %#x in round %v of set %v", ErrInvalidSignature, i, signed.ID,
+				j.Round, s.SetID)
+		}
+
+		err = ancestry.markDescendant(signed.Precommit, commit.TargetHash, commit.TargetNumber)
+		if err != nil {
+			return fmt.Errorf("precommit %v by %#x: %w", i, signed.ID, err)
+		}
+
+		if !voted[signed.ID] {
+			voted[signed.ID] = true
+			weight += authWeight
+		}
+	}
+
+	if weight < s.Threshold() {
+		return fmt.Errorf("%w: got %v, need %v of %v", ErrInsufficientWeight, weight, s.Threshold(), s.total)
+	}
+	if ancestry.unused() > 0 {
+		return fmt.Errorf("%w: %v of %v headers", ErrUnusedAncestry, ancestry.unused(), len(j.VotesAncestries))
+	}
+	return nil
+}
+
+// ancestryChain indexes the votes ancestries of a justification by hash to walk from precommit targets back to the
+// commit target, tracking which headers have been visited
+type ancestryChain struct {
+	headers map[types.Hash]types.Header
+	visited map[types.Hash]bool
+}
+
+func newAncestryChain(headers []types.Header) (*ancestryChain, error) {
+	a := &ancestryChain{
+		headers: make(map[types.Hash]types.Header, len(headers)),
+		visited: make(map[types.Hash]bool, len(headers)),
+	}
+	for _, h := range headers {
+		hash, err := types.GetHash(h)
+		if err != nil {
+			return nil, err
+		}
+		a.headers[hash] = h
+	}
+	return a, nil
+}
+
+// markDescendant checks that the target of the precommit is the block with the given hash and number or a descendant
+// of it, marking the headers in between as visited
+func (a *ancestryChain) markDescendant(precommit types.GrandpaPrecommit, hash types.Hash, number types.U32) error {
+	current, currentNumber := precommit.TargetHash, precommit.TargetNumber
+	for current != hash {
+		if currentNumber <= number {
+			return fmt.Errorf("%w: target %v (%#x)", ErrInvalidAncestry, precommit.TargetNumber,
+				precommit.TargetHash)
+		}
+		h, ok := a.headers[current]
+		if !ok || types.U32(h.Number) != currentNumber {
+			return fmt.Errorf("%w: missing header %v (%#x) in votes ancestries", ErrInvalidAncestry,
+				currentNumber, current)
+		}
+		a.visited[current] = true
+		current, currentNumber = h.ParentHash, currentNumber-1
+	}
+	return nil
+}
+
+// unused returns the number of headers not visited by markDescendant
+func (a *ancestryChain) unused() int {
+	return len(a.headers) - len(a.visited)
+}
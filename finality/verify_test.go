@@ -0,0 +1,186 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package finality
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// testChain is a chain of headers on top of a finalized target, so precommits can vote for descendants of it
+type testChain struct {
+	targetHash types.Hash
+	target     types.Header
+	headers    []types.Header
+}
+
+func newTestChain(t *testing.T, n int) testChain {
+	c := testChain{target: types.Header{ParentHash: types.Hash{1}, Number: 10}}
+	var err error
+	c.targetHash, err = types.GetHash(c.target)
+	assert.NoError(t, err)
+
+	parent := c.targetHash
+	for i := 0; i < n; i++ {
+		h := types.Header{ParentHash: parent, Number: types.BlockNumber(11 + i)}
+		c.headers = append(c.headers, h)
+		parent, err = types.GetHash(h)
+		assert.NoError(t, err)
+	}
+	return c
+}
+
+// precommit returns the precommit for the target of c if n is 0, or for the nth header on top of it
+func (c testChain) precommit(t *testing.T, n int) types.GrandpaPrecommit {
+	if n == 0 {
+		return types.GrandpaPrecommit{TargetHash: c.targetHash, TargetNumber: 10}
+	}
+	hash, err := types.GetHash(c.headers[n-1])
+	assert.NoError(t, err)
+	return types.GrandpaPrecommit{TargetHash: hash, TargetNumber: types.U32(c.headers[n-1].Number)}
+}
+
+func signPrecommit(t *testing.T, a testAuthority, precommit types.GrandpaPrecommit, round,
+	setID uint64) types.GrandpaSignedPrecommit {
+	payload, err := PrecommitSigningPayload(precommit, round, setID)
+	assert.NoError(t, err)
+	var sig types.Signature
+	copy(sig[:], ed25519.Sign(a.key, payload))
+	return types.GrandpaSignedPrecommit{Precommit: precommit, Signature: sig, ID: a.id}
+}
+
+func newTestJustification(t *testing.T, c testChain, authorities []testAuthority,
+	setID uint64) types.GrandpaJustification {
+	j := types.GrandpaJustification{
+		Round: 3,
+		Commit: types.GrandpaCommit{
+			TargetHash:   c.targetHash,
+			TargetNumber: 10,
+		},
+		VotesAncestries: c.headers,
+	}
+	for i, a := range authorities {
+		// vote for the target and for descendants up to the tip of the chain
+		n := i
+		if n > len(c.headers) {
+			n = len(c.headers)
+		}
+		j.Commit.Precommits = append(j.Commit.Precommits, signPrecommit(t, a, c.precommit(t, n), 3, setID))
+	}
+	return j
+}
+
+func TestAuthoritySet_VerifyJustification(t *testing.T) {
+	authorities := newTestAuthorities(4)
+	set := newTestAuthoritySet(t, 5, authorities)
+	c := newTestChain(t, 2)
+
+	j := newTestJustification(t, c, authorities, 5)
+	assert.NoError(t, set.VerifyJustification(c.targetHash, 10, j))
+
+	// 3 of 4 is enough
+	j.Commit.Precommits = j.Commit.Precommits[:3]
+	assert.NoError(t, set.VerifyJustification(c.targetHash, 10, j))
+
+	// an equivocation by an authority that also voted for another descendant is accepted
+	j.Commit.Precommits = append(j.Commit.Precommits, signPrecommit(t, authorities[0], c.precommit(t, 2), 3, 5))
+	assert.NoError(t, set.VerifyJustification(c.targetHash, 10, j))
+}
+
+func TestAuthoritySet_VerifyJustification_Errors(t *testing.T) {
+	authorities := newTestAuthorities(5)
+	set := newTestAuthoritySet(t, 5, authorities[:4])
+	c := newTestChain(t, 2)
+
+	j := newTestJustification(t, c, authorities[:4], 5)
+	err := set.VerifyJustification(types.Hash{9}, 10, j)
+	assert.ErrorIs(t, err, ErrTargetMismatch)
+	err = set.VerifyJustification(c.targetHash, 11, j)
+	assert.ErrorIs(t, err, ErrTargetMismatch)
+
+	// signed for another set
+	j = newTestJustification(t, c, authorities[:4], 4)
+	err = set.VerifyJustification(c.targetHash, 10, j)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	// tampered precommit
+	j = newTestJustification(t, c, authorities[:4], 5)
+	j.Commit.Precommits[0].Precommit.TargetNumber = 11
+	err = set.VerifyJustification(c.targetHash, 10, j)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	j = newTestJustification(t, c, authorities, 5)
+	err = set.VerifyJustification(c.targetHash, 10, j)
+	assert.ErrorIs(t, err, ErrUnknownAuthority)
+
+	j = newTestJustification(t, c, authorities[:2], 5)
+	err = set.VerifyJustification(c.targetHash, 10, j)
+	assert.ErrorIs(t, err, ErrInsufficientWeight)
+	assert.EqualError(t, err,
+		"precommits do not reach the threshold weight of the authority set: got 2, need 3 of 4")
+
+	// the weight of an equivocating authority is only counted once
+	j.Commit.Precommits = append(j.Commit.Precommits, signPrecommit(t, authorities[1], c.precommit(t, 2), 3, 5))
+	err = set.VerifyJustification(c.targetHash, 10, j)
+	assert.EqualError(t, err,
+		"precommits do not reach the threshold weight of the authority set: got 2, need 3 of 4")
+
+	// the precommits of an equivocation are checked like any other
+	j = newTestJustification(t, c, authorities[:4], 5)
+	j.Commit.Precommits = append(j.Commit.Precommits, signPrecommit(t, authorities[0], c.precommit(t, 2), 3, 4))
+	err = set.VerifyJustification(c.targetHash, 10, j)
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	j = newTestJustification(t, c, authorities[:4], 5)
+	other := types.GrandpaPrecommit{TargetHash: types.Hash{8}, TargetNumber: 9}
+	j.Commit.Precommits = append(j.Commit.Precommits, signPrecommit(t, authorities[0], other, 3, 5))
+	err = set.VerifyJustification(c.targetHash, 10, j)
+	assert.ErrorIs(t, err, ErrInvalidAncestry)
+
+	// a missing header breaks the ancestry of the precommits voting for descendants
+	j = newTestJustification(t, c, authorities[:4], 5)
+	j.VotesAncestries = c.headers[1:]
+	err = set.VerifyJustification(c.targetHash, 10, j)
+	assert.ErrorIs(t, err, ErrInvalidAncestry)
+
+	// precommits for blocks not descending from the target
+	j = newTestJustification(t, c, authorities[:1], 5)
+	other = types.GrandpaPrecommit{TargetHash: types.Hash{8}, TargetNumber: 9}
+	j.Commit.Precommits = append(j.Commit.Precommits, signPrecommit(t, authorities[1], other, 3, 5))
+	err = set.VerifyJustification(c.targetHash, 10, j)
+	assert.ErrorIs(t, err, ErrInvalidAncestry)
+
+	// only vote for the target, so the ancestries are not needed
+	j = newTestJustification(t, c, authorities[:4], 5)
+	for i, a := range authorities[:4] {
+		j.Commit.Precommits[i] = signPrecommit(t, a, c.precommit(t, 0), 3, 5)
+	}
+	err = set.VerifyJustification(c.targetHash, 10, j)
+	assert.ErrorIs(t, err, ErrUnusedAncestry)
+}
+
+func TestPrecommitSigningPayload(t *testing.T) {
+	payload, err := PrecommitSigningPayload(types.GrandpaPrecommit{TargetHash: types.Hash{0xab}, TargetNumber: 2},
+		3, 4)
+	assert.NoError(t, err)
+	assert.Equal(t, types.MustHexDecodeString(
+		"0x01ab00000000000000000000000000000000000000000000000000000000000000020000000300000000000000"+
+			"0400000000000000"), payload)
+}
@@ -215,6 +215,9 @@ type ClientSubscription struct {
 	quit     chan struct{} // quit is closed when the subscription exits
 	errOnce  sync.Once     // ensures err is closed once
 	err      chan error
+
+	// onUnsubscribe replaces the server unsubscribe request for subscriptions created with NewClientSubscription
+	onUnsubscribe func()
 }
 
 func newClientSubscription(c *Client, namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
@@ -234,6 +237,27 @@ func newClientSubscription(c *Client, namespace, subscribeMethodSuffix, unsubscr
 	return sub
 }
 
+// NewClientSubscription creates a ClientSubscription that is not bound to a server subscription of a Client. It
+// allows clients that manage the underlying server subscriptions themselves, e.g. to re-establish them after a
+// reconnect, to hand out a single subscription for their whole lifetime. Notifications have to be delivered to the
+// subscriber's channel by the caller, onUnsubscribe is called once when the subscription is unsubscribed.
+func NewClientSubscription(onUnsubscribe func()) *ClientSubscription {
+	return &ClientSubscription{
+		quit:          make(chan struct{}),
+		err:           make(chan error, 1),
+		onUnsubscribe: onUnsubscribe,
+	}
+}
+
+// Fail ends a subscription created with NewClientSubscription with the given error, which is delivered on the
+// error channel. ErrClientQuit is delivered as nil, adhering to the semantics of a closed client.
+func (sub *ClientSubscription) Fail(err error) {
+	if err == nil {
+		err = ErrClientQuit
+	}
+	sub.quitWithError(err, false)
+}
+
 // Err returns the subscription error channel. The intended use of Err is to schedule
 // resubscription when the client connection is closed unexpectedly.
 //
@@ -330,6 +354,10 @@ func (sub *ClientSubscription) unmarshal(result json.RawMessage) (interface{}, e
 }
 
 func (sub *ClientSubscription) requestUnsubscribe() error {
+	if sub.onUnsubscribe != nil {
+		sub.onUnsubscribe()
+		return nil
+	}
 	var result interface{}
 	return sub.client.Call(&result, sub.namespace+"_"+sub.unsubscribeMethodSuffix, sub.subid)
 }
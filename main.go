@@ -32,6 +32,12 @@ func NewSubstrateAPI(url string) (*SubstrateAPI, error) {
 		return nil, err
 	}
 
+	return NewSubstrateAPIWithClient(cl)
+}
+
+// NewSubstrateAPIWithClient creates a SubstrateAPI on top of an existing client, e.g. one created with
+// client.ConnectWithReconnect
+func NewSubstrateAPIWithClient(cl client.Client) (*SubstrateAPI, error) {
 	newRPC, err := rpc.NewRPC(cl)
 	if err != nil {
 		return nil, err
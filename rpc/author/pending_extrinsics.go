@@ -17,13 +17,20 @@
 package author
 
 import (
+	"context"
+
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // PendingExtrinsics returns all pending extrinsics, potentially grouped by sender
 func (a *Author) PendingExtrinsics() ([]types.Extrinsic, error) {
+	return a.PendingExtrinsicsCtx(context.Background())
+}
+
+// PendingExtrinsicsCtx returns all pending extrinsics, potentially grouped by sender, aborting if ctx is done
+func (a *Author) PendingExtrinsicsCtx(ctx context.Context) ([]types.Extrinsic, error) {
 	var res []string
-	err := a.client.Call(&res, "author_pendingExtrinsics")
+	err := a.client.CallContext(ctx, &res, "author_pendingExtrinsics")
 	if err != nil {
 		return nil, err
 	}
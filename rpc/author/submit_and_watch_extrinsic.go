@@ -66,6 +66,13 @@ func (a *Author) SubmitAndWatchExtrinsic(xt types.Extrinsic) (*ExtrinsicStatusSu
 	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
 	defer cancel()
 
+	return a.SubmitAndWatchExtrinsicCtx(ctx, xt)
+}
+
+// SubmitAndWatchExtrinsicCtx will submit and subscribe to watch an extrinsic like SubmitAndWatchExtrinsic, using ctx
+// instead of the default subscribe timeout to establish the subscription
+func (a *Author) SubmitAndWatchExtrinsicCtx(ctx context.Context, xt types.Extrinsic) (*ExtrinsicStatusSubscription,
+	error) {
 	c := make(chan types.ExtrinsicStatus)
 
 	enc, err := types.EncodeToHexString(xt)
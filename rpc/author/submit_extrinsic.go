@@ -16,17 +16,26 @@
 
 package author
 
-import "github.com/JFJun/go-substrate-rpc-client/v3/types"
+import (
+	"context"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
 
 // SubmitExtrinsic will submit a fully formatted extrinsic for block inclusion
 func (a *Author) SubmitExtrinsic(xt types.Extrinsic) (types.Hash, error) {
+	return a.SubmitExtrinsicCtx(context.Background(), xt)
+}
+
+// SubmitExtrinsicCtx will submit a fully formatted extrinsic for block inclusion, aborting if ctx is done
+func (a *Author) SubmitExtrinsicCtx(ctx context.Context, xt types.Extrinsic) (types.Hash, error) {
 	enc, err := types.EncodeToHexString(xt)
 	if err != nil {
 		return types.Hash{}, err
 	}
 
 	var res string
-	err = a.client.Call(&res, "author_submitExtrinsic", enc)
+	err = a.client.CallContext(ctx, &res, "author_submitExtrinsic", enc)
 	if err != nil {
 		return types.Hash{}, err
 	}
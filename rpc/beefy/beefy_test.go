@@ -0,0 +1,58 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beefy
+
+import (
+	"os"
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpcmocksrv"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+var beefy *Beefy
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+	err := s.RegisterName("beefy", &mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	beefy = NewBeefy(cl)
+
+	os.Exit(m.Run())
+}
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	finalizedHead types.Hash
+}
+
+func (s *MockSrv) GetFinalizedHead() string {
+	return mockSrv.finalizedHead.Hex()
+}
+
+// mockSrv sets default data used in tests
+var mockSrv = MockSrv{
+	finalizedHead: types.Hash{0xab, 0xcd, 0xef},
+}
@@ -17,23 +17,35 @@
 package chain
 
 import (
+	"context"
+
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // GetBlock returns the header and body of the relay chain block with the given hash
 func (c *Chain) GetBlock(blockHash types.Hash) (*types.SignedBlock, error) {
-	return c.getBlock(&blockHash)
+	return c.getBlock(context.Background(), &blockHash)
+}
+
+// GetBlockCtx returns the header and body of the relay chain block with the given hash, aborting if ctx is done
+func (c *Chain) GetBlockCtx(ctx context.Context, blockHash types.Hash) (*types.SignedBlock, error) {
+	return c.getBlock(ctx, &blockHash)
 }
 
 // GetBlockLatest returns the header and body of the latest relay chain block
 func (c *Chain) GetBlockLatest() (*types.SignedBlock, error) {
-	return c.getBlock(nil)
+	return c.getBlock(context.Background(), nil)
+}
+
+// GetBlockLatestCtx returns the header and body of the latest relay chain block, aborting if ctx is done
+func (c *Chain) GetBlockLatestCtx(ctx context.Context) (*types.SignedBlock, error) {
+	return c.getBlock(ctx, nil)
 }
 
-func (c *Chain) getBlock(blockHash *types.Hash) (*types.SignedBlock, error) {
+func (c *Chain) getBlock(ctx context.Context, blockHash *types.Hash) (*types.SignedBlock, error) {
 	var SignedBlock types.SignedBlock
-	err := client.CallWithBlockHash(c.client, &SignedBlock, "chain_getBlock", blockHash)
+	err := client.CallWithBlockHashContext(ctx, c.client, &SignedBlock, "chain_getBlock", blockHash)
 	if err != nil {
 		return nil, err
 	}
@@ -17,27 +17,78 @@
 package chain
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	gethrpc "github.com/JFJun/go-substrate-rpc-client/v3/gethrpc"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // GetBlockHash returns the block hash for a specific block height
 func (c *Chain) GetBlockHash(blockNumber uint64) (types.Hash, error) {
-	return c.getBlockHash(&blockNumber)
+	return c.getBlockHash(context.Background(), &blockNumber)
+}
+
+// GetBlockHashCtx returns the block hash for a specific block height, aborting if ctx is done
+func (c *Chain) GetBlockHashCtx(ctx context.Context, blockNumber uint64) (types.Hash, error) {
+	return c.getBlockHash(ctx, &blockNumber)
 }
 
 // GetBlockHashLatest returns the latest block hash
 func (c *Chain) GetBlockHashLatest() (types.Hash, error) {
-	return c.getBlockHash(nil)
+	return c.getBlockHash(context.Background(), nil)
+}
+
+// GetBlockHashLatestCtx returns the latest block hash, aborting if ctx is done
+func (c *Chain) GetBlockHashLatestCtx(ctx context.Context) (types.Hash, error) {
+	return c.getBlockHash(ctx, nil)
+}
+
+// GetBlockHashesBatch returns the block hashes for the given block heights using batched requests
+func (c *Chain) GetBlockHashesBatch(blockNumbers []uint64) ([]types.Hash, error) {
+	return c.GetBlockHashesBatchCtx(context.Background(), blockNumbers)
+}
+
+// GetBlockHashesBatchCtx returns the block hashes for the given block heights using batched requests, aborting if ctx
+// is done
+func (c *Chain) GetBlockHashesBatchCtx(ctx context.Context, blockNumbers []uint64) ([]types.Hash, error) {
+	res := make([]string, len(blockNumbers))
+	batch := make([]gethrpc.BatchElem, len(blockNumbers))
+	for i, blockNumber := range blockNumbers {
+		batch[i] = gethrpc.BatchElem{Method: "chain_getBlockHash", Args: []interface{}{blockNumber}, Result: &res[i]}
+	}
+
+	err := client.BatchCallInChunks(ctx, c.client, batch, client.DefaultBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]types.Hash, len(blockNumbers))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("unable to get block hash of block %v: %v", blockNumbers[i], elem.Error)
+		}
+		if res[i] == "" {
+			return nil, fmt.Errorf("block %v not found", blockNumbers[i])
+		}
+
+		hashes[i], err = types.NewHashFromHexString(res[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return hashes, nil
 }
 
-func (c *Chain) getBlockHash(blockNumber *uint64) (types.Hash, error) {
+func (c *Chain) getBlockHash(ctx context.Context, blockNumber *uint64) (types.Hash, error) {
 	var res string
 	var err error
 
 	if blockNumber == nil {
-		err = c.client.Call(&res, "chain_getBlockHash")
+		err = c.client.CallContext(ctx, &res, "chain_getBlockHash")
 	} else {
-		err = c.client.Call(&res, "chain_getBlockHash", *blockNumber)
+		err = c.client.CallContext(ctx, &res, "chain_getBlockHash", *blockNumber)
 	}
 
 	if err != nil {
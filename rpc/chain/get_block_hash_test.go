@@ -40,3 +40,17 @@ func TestChain_GetBlockHashLatest(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, blk.Block.Header.Number > 0)
 }
+
+func TestChain_GetBlockHashesBatch(t *testing.T) {
+	res, err := chain.GetBlockHashesBatch([]uint64{1, 2})
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+
+	first, err := chain.GetBlockHash(1)
+	assert.NoError(t, err)
+	assert.Equal(t, first, res[0])
+
+	blk, err := chain.GetBlock(res[1])
+	assert.NoError(t, err)
+	assert.Equal(t, types.BlockNumber(2), blk.Block.Header.Number)
+}
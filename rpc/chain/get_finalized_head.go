@@ -17,14 +17,21 @@
 package chain
 
 import (
+	"context"
+
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // GetFinalizedHead returns the hash of the last finalized block in the canon chain
 func (c *Chain) GetFinalizedHead() (types.Hash, error) {
+	return c.GetFinalizedHeadCtx(context.Background())
+}
+
+// GetFinalizedHeadCtx returns the hash of the last finalized block in the canon chain, aborting if ctx is done
+func (c *Chain) GetFinalizedHeadCtx(ctx context.Context) (types.Hash, error) {
 	var res string
 
-	err := c.client.Call(&res, "chain_getFinalizedHead")
+	err := c.client.CallContext(ctx, &res, "chain_getFinalizedHead")
 	if err != nil {
 		return types.Hash{}, err
 	}
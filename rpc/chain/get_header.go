@@ -17,23 +17,35 @@
 package chain
 
 import (
+	"context"
+
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // GetHeader retrieves the header for the specific block
 func (c *Chain) GetHeader(blockHash types.Hash) (*types.Header, error) {
-	return c.getHeader(&blockHash)
+	return c.getHeader(context.Background(), &blockHash)
+}
+
+// GetHeaderCtx retrieves the header for the specific block, aborting if ctx is done
+func (c *Chain) GetHeaderCtx(ctx context.Context, blockHash types.Hash) (*types.Header, error) {
+	return c.getHeader(ctx, &blockHash)
 }
 
 // GetHeaderLatest retrieves the header of the latest block
 func (c *Chain) GetHeaderLatest() (*types.Header, error) {
-	return c.getHeader(nil)
+	return c.getHeader(context.Background(), nil)
+}
+
+// GetHeaderLatestCtx retrieves the header of the latest block, aborting if ctx is done
+func (c *Chain) GetHeaderLatestCtx(ctx context.Context) (*types.Header, error) {
+	return c.getHeader(ctx, nil)
 }
 
-func (c *Chain) getHeader(blockHash *types.Hash) (*types.Header, error) {
+func (c *Chain) getHeader(ctx context.Context, blockHash *types.Hash) (*types.Header, error) {
 	var Header types.Header
-	err := client.CallWithBlockHash(c.client, &Header, "chain_getHeader", blockHash)
+	err := client.CallWithBlockHashContext(ctx, c.client, &Header, "chain_getHeader", blockHash)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +66,12 @@ func (c *Chain) SubscribeFinalizedHeads() (*FinalizedHeadsSubscription, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
 	defer cancel()
 
+	return c.SubscribeFinalizedHeadsCtx(ctx)
+}
+
+// SubscribeFinalizedHeadsCtx subscribes the best finalized headers like SubscribeFinalizedHeads, using ctx instead of
+// the default subscribe timeout to establish the subscription
+func (c *Chain) SubscribeFinalizedHeadsCtx(ctx context.Context) (*FinalizedHeadsSubscription, error) {
 	ch := make(chan types.Header)
 
 	sub, err := c.client.Subscribe(ctx, "chain", "subscribeFinalizedHeads", "unsubscribeFinalizedHeads",
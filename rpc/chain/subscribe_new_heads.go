@@ -66,6 +66,12 @@ func (c *Chain) SubscribeNewHeads() (*NewHeadsSubscription, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
 	defer cancel()
 
+	return c.SubscribeNewHeadsCtx(ctx)
+}
+
+// SubscribeNewHeadsCtx subscribes the best headers like SubscribeNewHeads, using ctx instead of the default subscribe
+// timeout to establish the subscription
+func (c *Chain) SubscribeNewHeadsCtx(ctx context.Context) (*NewHeadsSubscription, error) {
 	ch := make(chan types.Header)
 
 	sub, err := c.client.Subscribe(ctx, "chain", "subscribeNewHead", "unsubscribeNewHead", "newHead", ch)
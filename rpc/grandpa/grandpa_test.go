@@ -0,0 +1,107 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"os"
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpcmocksrv"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+var grandpa *Grandpa
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+	err := s.RegisterName("grandpa", &mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	grandpa = NewGrandpa(cl)
+
+	os.Exit(m.Run())
+}
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	roundState      types.ReportedRoundStates
+	finalizedNumber uint64
+	finalityProof   types.FinalityProof
+}
+
+func (s *MockSrv) RoundState() types.ReportedRoundStates {
+	return mockSrv.roundState
+}
+
+// ProveFinality returns null for blocks that are not finalized yet, like a node does
+func (s *MockSrv) ProveFinality(blockNumber uint64) *string {
+	if blockNumber > mockSrv.finalizedNumber {
+		return nil
+	}
+	enc, err := types.EncodeToHexString(mockSrv.finalityProof)
+	if err != nil {
+		panic(err)
+	}
+	return &enc
+}
+
+// mockSrv sets default data used in tests
+var mockSrv = MockSrv{
+	roundState: types.ReportedRoundStates{
+		SetID: 3,
+		Best: types.RoundState{
+			Round:           1090,
+			TotalWeight:     4,
+			ThresholdWeight: 3,
+			Prevotes:        types.Votes{CurrentWeight: 4, Missing: []string{}},
+			Precommits: types.Votes{CurrentWeight: 3, Missing: []string{
+				"5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu"}},
+		},
+		Background: []types.RoundState{},
+	},
+	finalizedNumber: 42,
+	finalityProof: types.FinalityProof{
+		Block: types.Hash{1, 2, 3},
+		Justification: mustEncode(types.GrandpaJustification{
+			Round: 1089,
+			Commit: types.GrandpaCommit{
+				TargetHash:   types.Hash{1, 2, 3},
+				TargetNumber: 42,
+				Precommits: []types.GrandpaSignedPrecommit{{
+					Precommit: types.GrandpaPrecommit{TargetHash: types.Hash{1, 2, 3}, TargetNumber: 42},
+					Signature: types.Signature{4, 5, 6},
+					ID:        types.AuthorityID{7, 8, 9},
+				}},
+			},
+		}),
+	},
+}
+
+func mustEncode(value interface{}) []byte {
+	bz, err := types.EncodeToBytes(value)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
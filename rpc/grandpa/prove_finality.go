@@ -0,0 +1,45 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"context"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// ProveFinality returns a proof of the finality of the given block, consisting of the justification of the block
+// finalizing it and the headers in between. Ok is false if the node cannot prove the finality of the block yet.
+func (g *Grandpa) ProveFinality(blockNumber uint64) (proof types.FinalityProof, ok bool, err error) {
+	return g.ProveFinalityCtx(context.Background(), blockNumber)
+}
+
+// ProveFinalityCtx is like ProveFinality, aborting the call if ctx is done
+func (g *Grandpa) ProveFinalityCtx(ctx context.Context, blockNumber uint64) (proof types.FinalityProof, ok bool,
+	err error) {
+	var res *string
+	err = g.client.CallContext(ctx, &res, "grandpa_proveFinality", blockNumber)
+	if err != nil || res == nil {
+		return types.FinalityProof{}, false, err
+	}
+
+	err = types.DecodeFromHexString(*res, &proof)
+	if err != nil {
+		return types.FinalityProof{}, false, err
+	}
+	return proof, true, nil
+}
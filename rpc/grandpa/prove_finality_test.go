@@ -0,0 +1,40 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrandpa_ProveFinality(t *testing.T) {
+	proof, ok, err := grandpa.ProveFinality(mockSrv.finalizedNumber)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, mockSrv.finalityProof, proof)
+
+	justification, err := proof.DecodeJustification()
+	assert.NoError(t, err)
+	assert.Equal(t, types.U32(42), justification.Commit.TargetNumber)
+	assert.Len(t, justification.Commit.Precommits, 1)
+
+	_, ok, err = grandpa.ProveFinality(mockSrv.finalizedNumber + 1)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,35 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"context"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// RoundState returns the state of the current best GRANDPA round and of the background rounds
+func (g *Grandpa) RoundState() (types.ReportedRoundStates, error) {
+	return g.RoundStateCtx(context.Background())
+}
+
+// RoundStateCtx is like RoundState, aborting the call if ctx is done
+func (g *Grandpa) RoundStateCtx(ctx context.Context) (types.ReportedRoundStates, error) {
+	var res types.ReportedRoundStates
+	err := g.client.CallContext(ctx, &res, "grandpa_roundState")
+	return res, err
+}
@@ -0,0 +1,85 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grandpa
+
+import (
+	"context"
+	"sync"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/config"
+	gethrpc "github.com/JFJun/go-substrate-rpc-client/v3/gethrpc"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// JustificationsSubscription is a subscription established through one of the Client's subscribe methods.
+type JustificationsSubscription struct {
+	sub      *gethrpc.ClientSubscription
+	channel  chan types.GrandpaJustification
+	quitOnce sync.Once // ensures quit is closed once
+}
+
+// Chan returns the subscription channel.
+//
+// The channel is closed when Unsubscribe is called on the subscription.
+func (s *JustificationsSubscription) Chan() <-chan types.GrandpaJustification {
+	return s.channel
+}
+
+// Err returns the subscription error channel. The intended use of Err is to schedule
+// resubscription when the client connection is closed unexpectedly.
+//
+// The error channel receives a value when the subscription has ended due
+// to an error. The received error is nil if Close has been called
+// on the underlying client and no other error has occurred.
+//
+// The error channel is closed when Unsubscribe is called on the subscription.
+func (s *JustificationsSubscription) Err() <-chan error {
+	return s.sub.Err()
+}
+
+// Unsubscribe unsubscribes the notification and closes the error channel.
+// It can safely be called more than once.
+func (s *JustificationsSubscription) Unsubscribe() {
+	s.sub.Unsubscribe()
+	s.quitOnce.Do(func() {
+		close(s.channel)
+	})
+}
+
+// SubscribeJustifications subscribes the GRANDPA justifications of finalized blocks, returning a subscription that
+// will receive server notifications containing the decoded GrandpaJustification. Note that nodes only send
+// justifications for blocks that are finalized with one, which is not every finalized block.
+func (g *Grandpa) SubscribeJustifications() (*JustificationsSubscription, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
+	defer cancel()
+
+	return g.SubscribeJustificationsCtx(ctx)
+}
+
+// SubscribeJustificationsCtx subscribes the GRANDPA justifications like SubscribeJustifications, using ctx instead of
+// the default subscribe timeout to establish the subscription
+func (g *Grandpa) SubscribeJustificationsCtx(ctx context.Context) (*JustificationsSubscription, error) {
+	ch := make(chan types.GrandpaJustification)
+
+	sub, err := g.client.Subscribe(ctx, "grandpa", "subscribeJustifications", "unsubscribeJustifications",
+		"justifications", ch)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JustificationsSubscription{sub: sub, channel: ch}, nil
+}
@@ -19,8 +19,12 @@ package rpc
 import (
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/author"
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/beefy"
 	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/chain"
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/grandpa"
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/mmr"
 	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/offchain"
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/payment"
 	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/state"
 	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/system"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
@@ -28,8 +32,12 @@ import (
 
 type RPC struct {
 	Author   *author.Author
+	Beefy    *beefy.Beefy
 	Chain    *chain.Chain
+	Grandpa  *grandpa.Grandpa
+	Mmr      *mmr.Mmr
 	Offchain *offchain.Offchain
+	Payment  *payment.Payment
 	State    *state.State
 	System   *system.System
 	client   client.Client
@@ -47,8 +55,12 @@ func NewRPC(cl client.Client) (*RPC, error) {
 
 	return &RPC{
 		Author:   author.NewAuthor(cl),
+		Beefy:    beefy.NewBeefy(cl),
 		Chain:    chain.NewChain(cl),
+		Grandpa:  grandpa.NewGrandpa(cl),
+		Mmr:      mmr.NewMmr(cl),
 		Offchain: offchain.NewOffchain(cl),
+		Payment:  payment.NewPayment(cl),
 		State:    st,
 		System:   system.NewSystem(cl),
 		client:   cl,
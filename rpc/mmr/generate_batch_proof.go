@@ -0,0 +1,58 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"context"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// GenerateBatchProof returns the leaves with the given indices and a single proof of them against the MMR root at the
+// given block
+func (m *Mmr) GenerateBatchProof(leafIndices []uint64, blockHash types.Hash) (types.MmrLeafBatchProof, error) {
+	return m.generateBatchProof(context.Background(), leafIndices, &blockHash)
+}
+
+// GenerateBatchProofCtx is like GenerateBatchProof, aborting the call if ctx is done
+func (m *Mmr) GenerateBatchProofCtx(ctx context.Context, leafIndices []uint64, blockHash types.Hash) (
+	types.MmrLeafBatchProof, error) {
+	return m.generateBatchProof(ctx, leafIndices, &blockHash)
+}
+
+// GenerateBatchProofLatest returns the leaves with the given indices and a single proof of them against the MMR root
+// at the latest block
+func (m *Mmr) GenerateBatchProofLatest(leafIndices []uint64) (types.MmrLeafBatchProof, error) {
+	return m.generateBatchProof(context.Background(), leafIndices, nil)
+}
+
+// GenerateBatchProofLatestCtx is like GenerateBatchProofLatest, aborting the call if ctx is done
+func (m *Mmr) GenerateBatchProofLatestCtx(ctx context.Context, leafIndices []uint64) (types.MmrLeafBatchProof,
+	error) {
+	return m.generateBatchProof(ctx, leafIndices, nil)
+}
+
+func (m *Mmr) generateBatchProof(ctx context.Context, leafIndices []uint64, blockHash *types.Hash) (
+	types.MmrLeafBatchProof, error) {
+	var res types.MmrLeafBatchProof
+	err := client.CallWithBlockHashContext(ctx, m.client, &res, "mmr_generateBatchProof", blockHash, leafIndices)
+	if err != nil {
+		return types.MmrLeafBatchProof{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,56 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"context"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// GenerateProof returns the leaf with the given index and a proof of it against the MMR root at the given block
+func (m *Mmr) GenerateProof(leafIndex uint64, blockHash types.Hash) (types.MmrLeafProof, error) {
+	return m.generateProof(context.Background(), leafIndex, &blockHash)
+}
+
+// GenerateProofCtx is like GenerateProof, aborting the call if ctx is done
+func (m *Mmr) GenerateProofCtx(ctx context.Context, leafIndex uint64, blockHash types.Hash) (types.MmrLeafProof,
+	error) {
+	return m.generateProof(ctx, leafIndex, &blockHash)
+}
+
+// GenerateProofLatest returns the leaf with the given index and a proof of it against the MMR root at the latest
+// block
+func (m *Mmr) GenerateProofLatest(leafIndex uint64) (types.MmrLeafProof, error) {
+	return m.generateProof(context.Background(), leafIndex, nil)
+}
+
+// GenerateProofLatestCtx is like GenerateProofLatest, aborting the call if ctx is done
+func (m *Mmr) GenerateProofLatestCtx(ctx context.Context, leafIndex uint64) (types.MmrLeafProof, error) {
+	return m.generateProof(ctx, leafIndex, nil)
+}
+
+func (m *Mmr) generateProof(ctx context.Context, leafIndex uint64, blockHash *types.Hash) (types.MmrLeafProof,
+	error) {
+	var res types.MmrLeafProof
+	err := client.CallWithBlockHashContext(ctx, m.client, &res, "mmr_generateProof", blockHash, leafIndex)
+	if err != nil {
+		return types.MmrLeafProof{}, err
+	}
+	return res, nil
+}
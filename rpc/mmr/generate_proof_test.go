@@ -0,0 +1,38 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMmr_GenerateProof(t *testing.T) {
+	proof, err := mmr.GenerateProof(3, mockSrv.blockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, types.MmrLeafProof{
+		BlockHash: mockSrv.blockHash,
+		Leaf:      newTestLeaf(3),
+		Proof:     types.MmrProof{LeafIndex: 3, LeafCount: mockSrv.leafCount, Items: mockSrv.items},
+	}, proof)
+
+	latest, err := mmr.GenerateProofLatest(3)
+	assert.NoError(t, err)
+	assert.Equal(t, proof, latest)
+}
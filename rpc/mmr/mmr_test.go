@@ -0,0 +1,94 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mmr
+
+import (
+	"os"
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpcmocksrv"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+var mmr *Mmr
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+	err := s.RegisterName("mmr", &mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	mmr = NewMmr(cl)
+
+	os.Exit(m.Run())
+}
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	blockHash types.Hash
+	leafCount types.U64
+	items     []types.H256
+}
+
+// GenerateProof returns a proof of a leaf whose parent number is the leaf index
+func (s *MockSrv) GenerateProof(leafIndex uint64, hash *string) types.MmrLeafProof {
+	if hash != nil && *hash != mockSrv.blockHash.Hex() {
+		panic("unknown block hash")
+	}
+	return types.MmrLeafProof{
+		BlockHash: mockSrv.blockHash,
+		Leaf:      newTestLeaf(leafIndex),
+		Proof:     types.MmrProof{LeafIndex: types.U64(leafIndex), LeafCount: mockSrv.leafCount, Items: mockSrv.items},
+	}
+}
+
+func (s *MockSrv) GenerateBatchProof(leafIndices []uint64, hash *string) types.MmrLeafBatchProof {
+	if hash != nil && *hash != mockSrv.blockHash.Hex() {
+		panic("unknown block hash")
+	}
+	p := types.MmrLeafBatchProof{
+		BlockHash: mockSrv.blockHash,
+		Proof:     types.MmrBatchProof{LeafCount: mockSrv.leafCount, Items: mockSrv.items},
+	}
+	for _, i := range leafIndices {
+		p.Leaves = append(p.Leaves, newTestLeaf(i))
+		p.Proof.LeafIndices = append(p.Proof.LeafIndices, types.U64(i))
+	}
+	return p
+}
+
+// mockSrv sets default data used in tests
+var mockSrv = MockSrv{
+	blockHash: types.NewHash(types.MustHexDecodeString(
+		"0xdd1816b6f6889f46e23b0d6750b0d3a6a7c2de1ab4a4f8c3e7dc0b1c2a3d4e5f")),
+	leafCount: 7,
+	items:     []types.H256{{1}, {2}, {3}},
+}
+
+func newTestLeaf(index uint64) types.MmrLeaf {
+	return types.MmrLeaf{
+		ParentNumberAndHash:   types.ParentNumberAndHash{ParentNumber: types.U32(index), Hash: types.Hash{0xab}},
+		BeefyNextAuthoritySet: types.BeefyNextAuthoritySet{ID: 1, Len: 4, Root: types.H256{0xcd}},
+		LeafExtra:             types.H256{0xef},
+	}
+}
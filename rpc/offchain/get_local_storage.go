@@ -1,6 +1,7 @@
 package offchain
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
@@ -18,6 +19,12 @@ const (
 
 // LocalStorageGet retrieves the stored data
 func (c *Offchain) LocalStorageGet(kind StorageKind, key []byte) (*types.StorageDataRaw, error) {
+	return c.LocalStorageGetCtx(context.Background(), kind, key)
+}
+
+// LocalStorageGetCtx retrieves the stored data, aborting if ctx is done
+func (c *Offchain) LocalStorageGetCtx(ctx context.Context, kind StorageKind, key []byte) (
+	*types.StorageDataRaw, error) {
 	var res string
 
 	kb, err := types.EncodeToHexString(key)
@@ -25,7 +32,7 @@ func (c *Offchain) LocalStorageGet(kind StorageKind, key []byte) (*types.Storage
 		return nil, fmt.Errorf("failed to encode key: %w", err)
 	}
 
-	err = c.client.Call(&res, "offchain_localStorageGet", kind, kb)
+	err = c.client.CallContext(ctx, &res, "offchain_localStorageGet", kind, kb)
 	if err != nil {
 		return nil, err
 	}
@@ -45,6 +52,11 @@ func (c *Offchain) LocalStorageGet(kind StorageKind, key []byte) (*types.Storage
 
 // LocalStorageSet saves the data
 func (c *Offchain) LocalStorageSet(kind StorageKind, key []byte, value []byte) error {
+	return c.LocalStorageSetCtx(context.Background(), kind, key, value)
+}
+
+// LocalStorageSetCtx saves the data, aborting if ctx is done
+func (c *Offchain) LocalStorageSetCtx(ctx context.Context, kind StorageKind, key []byte, value []byte) error {
 	var res string
 
 	kb, err := types.EncodeToHexString(key)
@@ -57,7 +69,7 @@ func (c *Offchain) LocalStorageSet(kind StorageKind, key []byte, value []byte) e
 		return fmt.Errorf("failed to encode value: %w", err)
 	}
 
-	err = c.client.Call(&res, "offchain_localStorageSet", kind, kb, vb)
+	err = c.client.CallContext(ctx, &res, "offchain_localStorageSet", kind, kb, vb)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,51 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"context"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/signature"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// EstimateFee estimates the partial fee, i.e. the fee without the tip, of sending the extrinsic ext from sender with
+// the given signature options at the latest block. Unsigned extrinsics are signed with a dummy signature of type t
+// first, with the extra data of the signed extensions declared by the metadata m, so the estimate accounts for the
+// length of the signature without requiring the private key of sender.
+func (p *Payment) EstimateFee(ext types.Extrinsic, sender types.MultiAddress, t signature.SignatureType,
+	m *types.Metadata, o types.SignatureOptions) (types.U128, error) {
+	return p.EstimateFeeCtx(context.Background(), ext, sender, t, m, o)
+}
+
+// EstimateFeeCtx is like EstimateFee, aborting the call if ctx is done
+func (p *Payment) EstimateFeeCtx(ctx context.Context, ext types.Extrinsic, sender types.MultiAddress,
+	t signature.SignatureType, m *types.Metadata, o types.SignatureOptions) (types.U128, error) {
+	if !ext.IsSigned() {
+		var err error
+		ext, err = ext.WithDummySignature(sender, t, m, o)
+		if err != nil {
+			return types.U128{}, err
+		}
+	}
+
+	info, err := p.queryInfo(ctx, ext, nil)
+	if err != nil {
+		return types.U128{}, err
+	}
+	return info.PartialFee, nil
+}
@@ -0,0 +1,64 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/signature"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayment_EstimateFee(t *testing.T) {
+	o := types.SignatureOptions{Nonce: types.NewUCompactFromUInt(1), Tip: types.NewUCompactFromUInt(2)}
+	sender := types.NewMultiAddressFromAccountID(signature.TestKeyringPairAlice.PublicKey)
+
+	fee, err := payment.EstimateFee(newTestExtrinsic(), sender, signature.SignatureTypeSr25519,
+		types.ExamplaryMetadataV4, o)
+	assert.NoError(t, err)
+
+	// the estimate matches the fee of the extrinsic signed for real
+	signed := newTestExtrinsic()
+	err = signed.Sign(signature.TestKeyringPairAlice, o)
+	assert.NoError(t, err)
+	info, err := payment.QueryInfoLatest(signed)
+	assert.NoError(t, err)
+	assert.Equal(t, info.PartialFee, fee)
+	assert.Equal(t, 1, fee.Cmp(big.NewInt(0)))
+
+	// signed extrinsics are queried as they are
+	fee, err = payment.EstimateFee(signed, sender, signature.SignatureTypeSr25519, types.ExamplaryMetadataV4, o)
+	assert.NoError(t, err)
+	assert.Equal(t, info.PartialFee, fee)
+
+	// the dummy signature of ecdsa signers is longer
+	signer, err := signature.KeyringPairFromSecretWithType("//Alice", 42, signature.SignatureTypeEcdsa)
+	assert.NoError(t, err)
+	sender = types.NewMultiAddressFromAccountID(signature.AccountID(signer.PublicKey, signature.SignatureTypeEcdsa))
+	fee, err = payment.EstimateFee(newTestExtrinsic(), sender, signature.SignatureTypeEcdsa, types.ExamplaryMetadataV4,
+		o)
+	assert.NoError(t, err)
+
+	signed = newTestExtrinsic()
+	err = signed.Sign(signer, o)
+	assert.NoError(t, err)
+	info, err = payment.QueryInfoLatest(signed)
+	assert.NoError(t, err)
+	assert.Equal(t, info.PartialFee, fee)
+}
@@ -0,0 +1,108 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpcmocksrv"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+var payment *Payment
+
+func TestMain(m *testing.M) {
+	s := rpcmocksrv.New()
+	err := s.RegisterName("payment", &mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	payment = NewPayment(cl)
+
+	os.Exit(m.Run())
+}
+
+// MockSrv holds data and methods exposed by the RPC Mock Server used in integration tests
+type MockSrv struct {
+	blockHash  types.Hash
+	weight     types.Weight
+	feePerByte int64
+	feeDetails types.FeeDetails
+}
+
+// QueryInfo charges feePerByte for every byte of signed extrinsics, mirroring that the node only charges those
+func (s *MockSrv) QueryInfo(ext string, hash *string) types.RuntimeDispatchInfo {
+	if hash != nil && *hash != mockSrv.blockHash.Hex() {
+		panic("unknown block hash")
+	}
+
+	var e types.Extrinsic
+	err := types.DecodeFromHexString(ext, &e)
+	if err != nil {
+		panic(err)
+	}
+
+	fee := big.NewInt(0)
+	if e.IsSigned() {
+		fee.SetInt64(int64(len(types.MustHexDecodeString(ext))) * mockSrv.feePerByte)
+	}
+	return types.RuntimeDispatchInfo{
+		Weight:     mockSrv.weight,
+		Class:      types.DispatchClass{IsNormal: true},
+		PartialFee: types.NewU128(*fee),
+	}
+}
+
+func (s *MockSrv) QueryFeeDetails(ext string, hash *string) types.FeeDetails {
+	if hash != nil && *hash != mockSrv.blockHash.Hex() {
+		panic("unknown block hash")
+	}
+	return mockSrv.feeDetails
+}
+
+// mockSrv sets default data used in tests
+var mockSrv = MockSrv{
+	blockHash: types.NewHash(types.MustHexDecodeString(
+		"0xdd1816b6f6889f46e23b0d6750b0d3a6a7c2de1ab4a4f8c3e7dc0b1c2a3d4e5f")),
+	weight:     195000000,
+	feePerByte: 1000,
+	feeDetails: types.FeeDetails{HasInclusionFee: true, InclusionFee: types.InclusionFee{
+		BaseFee:           types.NewU128(*big.NewInt(1000000000)),
+		LenFee:            types.NewU128(*big.NewInt(143000)),
+		AdjustedWeightFee: types.NewU128(*big.NewInt(195000000)),
+	}},
+}
+
+// newTestExtrinsic returns an unsigned balances transfer
+func newTestExtrinsic() types.Extrinsic {
+	c, err := types.NewCall(types.ExamplaryMetadataV4, "balances.transfer",
+		types.NewAddressFromAccountID(types.MustHexDecodeString(
+			"0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")),
+		types.NewUCompactFromUInt(6969))
+	if err != nil {
+		panic(err)
+	}
+	return types.NewExtrinsic(c)
+}
@@ -0,0 +1,61 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"context"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// QueryFeeDetails retrieves the breakdown of the inclusion fee of the given extrinsic at the given block into base fee,
+// length fee and adjusted weight fee
+func (p *Payment) QueryFeeDetails(ext types.Extrinsic, blockHash types.Hash) (types.FeeDetails, error) {
+	return p.QueryFeeDetailsCtx(context.Background(), ext, blockHash)
+}
+
+// QueryFeeDetailsCtx is like QueryFeeDetails, aborting the call if ctx is done
+func (p *Payment) QueryFeeDetailsCtx(ctx context.Context, ext types.Extrinsic, blockHash types.Hash) (
+	types.FeeDetails, error) {
+	return p.queryFeeDetails(ctx, ext, &blockHash)
+}
+
+// QueryFeeDetailsLatest retrieves the breakdown of the inclusion fee of the given extrinsic at the latest block
+func (p *Payment) QueryFeeDetailsLatest(ext types.Extrinsic) (types.FeeDetails, error) {
+	return p.QueryFeeDetailsLatestCtx(context.Background(), ext)
+}
+
+// QueryFeeDetailsLatestCtx is like QueryFeeDetailsLatest, aborting the call if ctx is done
+func (p *Payment) QueryFeeDetailsLatestCtx(ctx context.Context, ext types.Extrinsic) (types.FeeDetails, error) {
+	return p.queryFeeDetails(ctx, ext, nil)
+}
+
+func (p *Payment) queryFeeDetails(ctx context.Context, ext types.Extrinsic, blockHash *types.Hash) (
+	types.FeeDetails, error) {
+	enc, err := types.EncodeToHexString(ext)
+	if err != nil {
+		return types.FeeDetails{}, err
+	}
+
+	var res types.FeeDetails
+	err = client.CallWithBlockHashContext(ctx, p.client, &res, "payment_queryFeeDetails", blockHash, enc)
+	if err != nil {
+		return types.FeeDetails{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,61 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"context"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// QueryInfo retrieves the weight, class and partial fee of the given extrinsic at the given block. The extrinsic must
+// be signed for the fee to be computed, see EstimateFee for unsigned ones.
+func (p *Payment) QueryInfo(ext types.Extrinsic, blockHash types.Hash) (types.RuntimeDispatchInfo, error) {
+	return p.QueryInfoCtx(context.Background(), ext, blockHash)
+}
+
+// QueryInfoCtx is like QueryInfo, aborting the call if ctx is done
+func (p *Payment) QueryInfoCtx(ctx context.Context, ext types.Extrinsic, blockHash types.Hash) (
+	types.RuntimeDispatchInfo, error) {
+	return p.queryInfo(ctx, ext, &blockHash)
+}
+
+// QueryInfoLatest retrieves the weight, class and partial fee of the given extrinsic at the latest block
+func (p *Payment) QueryInfoLatest(ext types.Extrinsic) (types.RuntimeDispatchInfo, error) {
+	return p.QueryInfoLatestCtx(context.Background(), ext)
+}
+
+// QueryInfoLatestCtx is like QueryInfoLatest, aborting the call if ctx is done
+func (p *Payment) QueryInfoLatestCtx(ctx context.Context, ext types.Extrinsic) (types.RuntimeDispatchInfo, error) {
+	return p.queryInfo(ctx, ext, nil)
+}
+
+func (p *Payment) queryInfo(ctx context.Context, ext types.Extrinsic, blockHash *types.Hash) (
+	types.RuntimeDispatchInfo, error) {
+	enc, err := types.EncodeToHexString(ext)
+	if err != nil {
+		return types.RuntimeDispatchInfo{}, err
+	}
+
+	var res types.RuntimeDispatchInfo
+	err = client.CallWithBlockHashContext(ctx, p.client, &res, "payment_queryInfo", blockHash, enc)
+	if err != nil {
+		return types.RuntimeDispatchInfo{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,46 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package payment
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/signature"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayment_QueryInfo(t *testing.T) {
+	ext := newTestExtrinsic()
+	err := ext.Sign(signature.TestKeyringPairAlice, types.SignatureOptions{Nonce: types.NewUCompactFromUInt(1)})
+	assert.NoError(t, err)
+	enc, err := types.EncodeToBytes(ext)
+	assert.NoError(t, err)
+
+	info, err := payment.QueryInfo(ext, mockSrv.blockHash)
+	assert.NoError(t, err)
+	assert.Equal(t, types.RuntimeDispatchInfo{
+		Weight:     mockSrv.weight,
+		Class:      types.DispatchClass{IsNormal: true},
+		PartialFee: types.NewU128(*big.NewInt(int64(len(enc)) * mockSrv.feePerByte)),
+	}, info)
+
+	latest, err := payment.QueryInfoLatest(ext)
+	assert.NoError(t, err)
+	assert.Equal(t, info, latest)
+}
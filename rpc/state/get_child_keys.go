@@ -17,6 +17,7 @@
 package state
 
 import (
+	"context"
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
@@ -24,18 +25,31 @@ import (
 // GetChildKeys retreives the keys with the given prefix of a specific child storage
 func (s *State) GetChildKeys(childStorageKey, prefix types.StorageKey, blockHash types.Hash) (
 	[]types.StorageKey, error) {
-	return s.getChildKeys(childStorageKey, prefix, &blockHash)
+	return s.GetChildKeysCtx(context.Background(), childStorageKey, prefix, blockHash)
+}
+
+// GetChildKeysCtx is like GetChildKeys, aborting the call if ctx is done
+func (s *State) GetChildKeysCtx(ctx context.Context, childStorageKey, prefix types.StorageKey, blockHash types.Hash) (
+	[]types.StorageKey, error) {
+	return s.getChildKeys(ctx, childStorageKey, prefix, &blockHash)
 }
 
 // GetChildKeysLatest retreives the keys with the given prefix of a specific child storage for the latest block height
 func (s *State) GetChildKeysLatest(childStorageKey, prefix types.StorageKey) ([]types.StorageKey, error) {
-	return s.getChildKeys(childStorageKey, prefix, nil)
+	return s.GetChildKeysLatestCtx(context.Background(), childStorageKey, prefix)
+}
+
+// GetChildKeysLatestCtx is like GetChildKeysLatest, aborting the call if ctx is done
+func (s *State) GetChildKeysLatestCtx(ctx context.Context, childStorageKey, prefix types.StorageKey) (
+	[]types.StorageKey, error) {
+	return s.getChildKeys(ctx, childStorageKey, prefix, nil)
 }
 
-func (s *State) getChildKeys(childStorageKey, prefix types.StorageKey, blockHash *types.Hash) (
+func (s *State) getChildKeys(ctx context.Context, childStorageKey, prefix types.StorageKey, blockHash *types.Hash) (
 	[]types.StorageKey, error) {
 	var res []string
-	err := client.CallWithBlockHash(s.client, &res, "state_getChildKeys", blockHash, childStorageKey.Hex(), prefix.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getChildKeys", blockHash, childStorageKey.Hex(),
+		prefix.Hex())
 	if err != nil {
 		return nil, err
 	}
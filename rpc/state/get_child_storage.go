@@ -17,6 +17,7 @@
 package state
 
 import (
+	"context"
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
@@ -25,7 +26,13 @@ import (
 // value is not empty.
 func (s *State) GetChildStorage(childStorageKey, key types.StorageKey, target interface{}, blockHash types.Hash) (
 	ok bool, err error) {
-	raw, err := s.getChildStorageRaw(childStorageKey, key, &blockHash)
+	return s.GetChildStorageCtx(context.Background(), childStorageKey, key, target, blockHash)
+}
+
+// GetChildStorageCtx is like GetChildStorage, aborting the call if ctx is done
+func (s *State) GetChildStorageCtx(ctx context.Context, childStorageKey, key types.StorageKey, target interface{},
+	blockHash types.Hash) (ok bool, err error) {
+	raw, err := s.getChildStorageRaw(ctx, childStorageKey, key, &blockHash)
 	if err != nil {
 		return false, err
 	}
@@ -38,7 +45,13 @@ func (s *State) GetChildStorage(childStorageKey, key types.StorageKey, target in
 // GetChildStorageLatest retreives the child storage for a key for the latest block height and decodes them into the
 // provided interface. Ok is true if the value is not empty.
 func (s *State) GetChildStorageLatest(childStorageKey, key types.StorageKey, target interface{}) (ok bool, err error) {
-	raw, err := s.getChildStorageRaw(childStorageKey, key, nil)
+	return s.GetChildStorageLatestCtx(context.Background(), childStorageKey, key, target)
+}
+
+// GetChildStorageLatestCtx is like GetChildStorageLatest, aborting the call if ctx is done
+func (s *State) GetChildStorageLatestCtx(ctx context.Context, childStorageKey, key types.StorageKey,
+	target interface{}) (ok bool, err error) {
+	raw, err := s.getChildStorageRaw(ctx, childStorageKey, key, nil)
 	if err != nil {
 		return false, err
 	}
@@ -51,20 +64,32 @@ func (s *State) GetChildStorageLatest(childStorageKey, key types.StorageKey, tar
 // GetChildStorageRaw retreives the child storage for a key as raw bytes, without decoding them
 func (s *State) GetChildStorageRaw(childStorageKey, key types.StorageKey, blockHash types.Hash) (
 	*types.StorageDataRaw, error) {
-	return s.getChildStorageRaw(childStorageKey, key, &blockHash)
+	return s.GetChildStorageRawCtx(context.Background(), childStorageKey, key, blockHash)
+}
+
+// GetChildStorageRawCtx is like GetChildStorageRaw, aborting the call if ctx is done
+func (s *State) GetChildStorageRawCtx(ctx context.Context, childStorageKey, key types.StorageKey,
+	blockHash types.Hash) (*types.StorageDataRaw, error) {
+	return s.getChildStorageRaw(ctx, childStorageKey, key, &blockHash)
 }
 
 // GetChildStorageRawLatest retreives the child storage for a key for the latest block height as raw bytes,
 // without decoding them
 func (s *State) GetChildStorageRawLatest(childStorageKey, key types.StorageKey) (*types.StorageDataRaw, error) {
-	return s.getChildStorageRaw(childStorageKey, key, nil)
+	return s.GetChildStorageRawLatestCtx(context.Background(), childStorageKey, key)
+}
+
+// GetChildStorageRawLatestCtx is like GetChildStorageRawLatest, aborting the call if ctx is done
+func (s *State) GetChildStorageRawLatestCtx(ctx context.Context, childStorageKey, key types.StorageKey) (
+	*types.StorageDataRaw, error) {
+	return s.getChildStorageRaw(ctx, childStorageKey, key, nil)
 }
 
-func (s *State) getChildStorageRaw(childStorageKey, key types.StorageKey, blockHash *types.Hash) (
+func (s *State) getChildStorageRaw(ctx context.Context, childStorageKey, key types.StorageKey, blockHash *types.Hash) (
 	*types.StorageDataRaw, error) {
 	var res string
-	err := client.CallWithBlockHash(s.client, &res, "state_getChildStorage", blockHash, childStorageKey.Hex(),
-		key.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getChildStorage", blockHash,
+		childStorageKey.Hex(), key.Hex())
 	if err != nil {
 		return nil, err
 	}
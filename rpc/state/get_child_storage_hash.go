@@ -17,24 +17,38 @@
 package state
 
 import (
+	"context"
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // GetChildStorageHash retreives the child storage hash for the given key
 func (s *State) GetChildStorageHash(childStorageKey, key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
-	return s.getChildStorageHash(childStorageKey, key, &blockHash)
+	return s.GetChildStorageHashCtx(context.Background(), childStorageKey, key, blockHash)
+}
+
+// GetChildStorageHashCtx is like GetChildStorageHash, aborting the call if ctx is done
+func (s *State) GetChildStorageHashCtx(ctx context.Context, childStorageKey, key types.StorageKey,
+	blockHash types.Hash) (types.Hash, error) {
+	return s.getChildStorageHash(ctx, childStorageKey, key, &blockHash)
 }
 
 // GetChildStorageHashLatest retreives the child storage hash for the given key for the latest block height
 func (s *State) GetChildStorageHashLatest(childStorageKey, key types.StorageKey) (types.Hash, error) {
-	return s.getChildStorageHash(childStorageKey, key, nil)
+	return s.GetChildStorageHashLatestCtx(context.Background(), childStorageKey, key)
+}
+
+// GetChildStorageHashLatestCtx is like GetChildStorageHashLatest, aborting the call if ctx is done
+func (s *State) GetChildStorageHashLatestCtx(ctx context.Context, childStorageKey, key types.StorageKey) (
+	types.Hash, error) {
+	return s.getChildStorageHash(ctx, childStorageKey, key, nil)
 }
 
-func (s *State) getChildStorageHash(childStorageKey, key types.StorageKey, blockHash *types.Hash) (types.Hash, error) {
+func (s *State) getChildStorageHash(ctx context.Context, childStorageKey, key types.StorageKey,
+	blockHash *types.Hash) (types.Hash, error) {
 	var res string
-	err := client.CallWithBlockHash(s.client, &res, "state_getChildStorageHash", blockHash, childStorageKey.Hex(),
-		key.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getChildStorageHash", blockHash,
+		childStorageKey.Hex(), key.Hex())
 	if err != nil {
 		return types.Hash{}, err
 	}
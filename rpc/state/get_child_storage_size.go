@@ -17,24 +17,38 @@
 package state
 
 import (
+	"context"
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // GetChildStorageSize retreives the child storage size for the given key
 func (s *State) GetChildStorageSize(childStorageKey, key types.StorageKey, blockHash types.Hash) (types.U64, error) {
-	return s.getChildStorageSize(childStorageKey, key, &blockHash)
+	return s.GetChildStorageSizeCtx(context.Background(), childStorageKey, key, blockHash)
+}
+
+// GetChildStorageSizeCtx is like GetChildStorageSize, aborting the call if ctx is done
+func (s *State) GetChildStorageSizeCtx(ctx context.Context, childStorageKey, key types.StorageKey,
+	blockHash types.Hash) (types.U64, error) {
+	return s.getChildStorageSize(ctx, childStorageKey, key, &blockHash)
 }
 
 // GetChildStorageSizeLatest retreives the child storage size for the given key for the latest block height
 func (s *State) GetChildStorageSizeLatest(childStorageKey, key types.StorageKey) (types.U64, error) {
-	return s.getChildStorageSize(childStorageKey, key, nil)
+	return s.GetChildStorageSizeLatestCtx(context.Background(), childStorageKey, key)
+}
+
+// GetChildStorageSizeLatestCtx is like GetChildStorageSizeLatest, aborting the call if ctx is done
+func (s *State) GetChildStorageSizeLatestCtx(ctx context.Context, childStorageKey, key types.StorageKey) (
+	types.U64, error) {
+	return s.getChildStorageSize(ctx, childStorageKey, key, nil)
 }
 
-func (s *State) getChildStorageSize(childStorageKey, key types.StorageKey, blockHash *types.Hash) (types.U64, error) {
+func (s *State) getChildStorageSize(ctx context.Context, childStorageKey, key types.StorageKey,
+	blockHash *types.Hash) (types.U64, error) {
 	var res types.U64
-	err := client.CallWithBlockHash(s.client, &res, "state_getChildStorageSize", blockHash, childStorageKey.Hex(),
-		key.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getChildStorageSize", blockHash,
+		childStorageKey.Hex(), key.Hex())
 	if err != nil {
 		return 0, err
 	}
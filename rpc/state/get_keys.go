@@ -17,23 +17,36 @@
 package state
 
 import (
+	"context"
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // GetKeys retreives the keys with the given prefix
 func (s *State) GetKeys(prefix types.StorageKey, blockHash types.Hash) ([]types.StorageKey, error) {
-	return s.getKeys(prefix, &blockHash)
+	return s.GetKeysCtx(context.Background(), prefix, blockHash)
+}
+
+// GetKeysCtx is like GetKeys, aborting the call if ctx is done
+func (s *State) GetKeysCtx(ctx context.Context, prefix types.StorageKey, blockHash types.Hash) (
+	[]types.StorageKey, error) {
+	return s.getKeys(ctx, prefix, &blockHash)
 }
 
 // GetKeysLatest retreives the keys with the given prefix for the latest block height
 func (s *State) GetKeysLatest(prefix types.StorageKey) ([]types.StorageKey, error) {
-	return s.getKeys(prefix, nil)
+	return s.GetKeysLatestCtx(context.Background(), prefix)
+}
+
+// GetKeysLatestCtx is like GetKeysLatest, aborting the call if ctx is done
+func (s *State) GetKeysLatestCtx(ctx context.Context, prefix types.StorageKey) ([]types.StorageKey, error) {
+	return s.getKeys(ctx, prefix, nil)
 }
 
-func (s *State) getKeys(prefix types.StorageKey, blockHash *types.Hash) ([]types.StorageKey, error) {
+func (s *State) getKeys(ctx context.Context, prefix types.StorageKey, blockHash *types.Hash) ([]types.StorageKey,
+	error) {
 	var res []string
-	err := client.CallWithBlockHash(s.client, &res, "state_getKeys", blockHash, prefix.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getKeys", blockHash, prefix.Hex())
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,75 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// GetKeysPaged retreives at most count keys with the given prefix that follow startKey in lexicographic order. Leave
+// startKey empty to start with the first key. Use a KeyIterator to walk all keys with the prefix.
+func (s *State) GetKeysPaged(prefix types.StorageKey, count uint32, startKey types.StorageKey, blockHash types.Hash) (
+	[]types.StorageKey, error) {
+	return s.GetKeysPagedCtx(context.Background(), prefix, count, startKey, blockHash)
+}
+
+// GetKeysPagedCtx is like GetKeysPaged, aborting the call if ctx is done
+func (s *State) GetKeysPagedCtx(ctx context.Context, prefix types.StorageKey, count uint32, startKey types.StorageKey,
+	blockHash types.Hash) ([]types.StorageKey, error) {
+	return s.getKeysPaged(ctx, prefix, count, startKey, &blockHash)
+}
+
+// GetKeysPagedLatest retreives at most count keys with the given prefix that follow startKey in lexicographic order
+// for the latest block height
+func (s *State) GetKeysPagedLatest(prefix types.StorageKey, count uint32, startKey types.StorageKey) (
+	[]types.StorageKey, error) {
+	return s.GetKeysPagedLatestCtx(context.Background(), prefix, count, startKey)
+}
+
+// GetKeysPagedLatestCtx is like GetKeysPagedLatest, aborting the call if ctx is done
+func (s *State) GetKeysPagedLatestCtx(ctx context.Context, prefix types.StorageKey, count uint32,
+	startKey types.StorageKey) ([]types.StorageKey, error) {
+	return s.getKeysPaged(ctx, prefix, count, startKey, nil)
+}
+
+func (s *State) getKeysPaged(ctx context.Context, prefix types.StorageKey, count uint32, startKey types.StorageKey,
+	blockHash *types.Hash) ([]types.StorageKey, error) {
+	var start *string
+	if len(startKey) > 0 {
+		hexStart := startKey.Hex()
+		start = &hexStart
+	}
+
+	var res []string
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getKeysPaged", blockHash, prefix.Hex(), count,
+		start)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]types.StorageKey, len(res))
+	for i, r := range res {
+		err = types.DecodeFromHexString(r, &keys[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
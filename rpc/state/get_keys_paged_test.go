@@ -0,0 +1,75 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_GetKeysPaged(t *testing.T) {
+	prefix := types.NewStorageKey(types.MustHexDecodeString(mockSrv.storageKeyHex))[:8]
+	keys, err := state.GetKeysPaged(prefix, 2, nil, mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.StorageKey{
+		types.MustHexDecodeString(mockSrv.storageKeyHex),
+		types.MustHexDecodeString(mockSrv.storageKeyHex + "00"),
+	}, keys)
+
+	keys, err = state.GetKeysPagedLatest(prefix, 2, keys[1])
+	assert.NoError(t, err)
+	assert.Equal(t, []types.StorageKey{
+		types.MustHexDecodeString(mockSrv.storageKeyHex + "01"),
+		types.MustHexDecodeString(mockSrv.storageKeyHex + "02"),
+	}, keys)
+}
+
+func TestKeyIterator(t *testing.T) {
+	prefix := types.NewStorageKey(types.MustHexDecodeString(mockSrv.storageKeyHex))
+	// a page size that divides the number of keys, so that the last page is empty
+	it := state.NewKeyIterator(prefix, 5, mockSrv.blockHashLatest)
+
+	var keys []types.StorageKey
+	for it.Next() {
+		keys = append(keys, it.Key())
+		assert.Nil(t, it.Value())
+	}
+	assert.NoError(t, it.Err())
+	assert.Len(t, keys, mockSrv.pagedKeys+1)
+	assert.Equal(t, types.StorageKey(types.MustHexDecodeString(mockSrv.storageKeyHex)), keys[0])
+	assert.Equal(t, types.StorageKey(types.MustHexDecodeString(mockSrv.storageKeyHex+"17")), keys[len(keys)-1])
+	assert.False(t, it.Next())
+}
+
+func TestKeyIterator_WithValues(t *testing.T) {
+	prefix := types.NewStorageKey(types.MustHexDecodeString(mockSrv.storageKeyHex))
+	it := state.NewKeyIterator(prefix, 7, mockSrv.blockHashLatest).WithValues()
+
+	n := 0
+	for it.Next() {
+		if n == 0 {
+			assert.Equal(t, mockSrv.storageDataHex, it.Value().Hex())
+		} else {
+			assert.Len(t, *it.Value(), 0)
+		}
+		n++
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, mockSrv.pagedKeys+1, n)
+}
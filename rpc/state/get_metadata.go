@@ -17,23 +17,34 @@
 package state
 
 import (
+	"context"
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // GetMetadata returns the metadata at the given block
 func (s *State) GetMetadata(blockHash types.Hash) (*types.Metadata, error) {
-	return s.getMetadata(&blockHash)
+	return s.GetMetadataCtx(context.Background(), blockHash)
+}
+
+// GetMetadataCtx is like GetMetadata, aborting the call if ctx is done
+func (s *State) GetMetadataCtx(ctx context.Context, blockHash types.Hash) (*types.Metadata, error) {
+	return s.getMetadata(ctx, &blockHash)
 }
 
 // GetMetadataLatest returns the latest metadata
 func (s *State) GetMetadataLatest() (*types.Metadata, error) {
-	return s.getMetadata(nil)
+	return s.GetMetadataLatestCtx(context.Background())
+}
+
+// GetMetadataLatestCtx is like GetMetadataLatest, aborting the call if ctx is done
+func (s *State) GetMetadataLatestCtx(ctx context.Context) (*types.Metadata, error) {
+	return s.getMetadata(ctx, nil)
 }
 
-func (s *State) getMetadata(blockHash *types.Hash) (*types.Metadata, error) {
+func (s *State) getMetadata(ctx context.Context, blockHash *types.Hash) (*types.Metadata, error) {
 	var res string
-	err := client.CallWithBlockHash(s.client, &res, "state_getMetadata", blockHash)
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getMetadata", blockHash)
 	if err != nil {
 		return nil, err
 	}
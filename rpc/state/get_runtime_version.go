@@ -17,23 +17,34 @@
 package state
 
 import (
+	"context"
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // GetRuntimeVersion returns the runtime version at the given block
 func (s *State) GetRuntimeVersion(blockHash types.Hash) (*types.RuntimeVersion, error) {
-	return s.getRuntimeVersion(&blockHash)
+	return s.GetRuntimeVersionCtx(context.Background(), blockHash)
+}
+
+// GetRuntimeVersionCtx is like GetRuntimeVersion, aborting the call if ctx is done
+func (s *State) GetRuntimeVersionCtx(ctx context.Context, blockHash types.Hash) (*types.RuntimeVersion, error) {
+	return s.getRuntimeVersion(ctx, &blockHash)
 }
 
 // GetRuntimeVersionLatest returns the latest runtime version
 func (s *State) GetRuntimeVersionLatest() (*types.RuntimeVersion, error) {
-	return s.getRuntimeVersion(nil)
+	return s.GetRuntimeVersionLatestCtx(context.Background())
+}
+
+// GetRuntimeVersionLatestCtx is like GetRuntimeVersionLatest, aborting the call if ctx is done
+func (s *State) GetRuntimeVersionLatestCtx(ctx context.Context) (*types.RuntimeVersion, error) {
+	return s.getRuntimeVersion(ctx, nil)
 }
 
-func (s *State) getRuntimeVersion(blockHash *types.Hash) (*types.RuntimeVersion, error) {
+func (s *State) getRuntimeVersion(ctx context.Context, blockHash *types.Hash) (*types.RuntimeVersion, error) {
 	var runtimeVersion types.RuntimeVersion
-	err := client.CallWithBlockHash(s.client, &runtimeVersion, "state_getRuntimeVersion", blockHash)
+	err := client.CallWithBlockHashContext(ctx, s.client, &runtimeVersion, "state_getRuntimeVersion", blockHash)
 	if err != nil {
 		return nil, err
 	}
@@ -17,6 +17,7 @@
 package state
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -33,3 +34,11 @@ func TestState_GetRuntimeVersion(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, &mockSrv.runtimeVersion, rv)
 }
+
+func TestState_GetRuntimeVersionLatestCtx_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := state.GetRuntimeVersionLatestCtx(ctx)
+	assert.EqualError(t, err, context.Canceled.Error())
+}
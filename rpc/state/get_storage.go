@@ -17,6 +17,7 @@
 package state
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
@@ -26,7 +27,13 @@ import (
 // GetStorage retreives the stored data and decodes them into the provided interface. Ok is true if the value is not
 // empty.
 func (s *State) GetStorage(key types.StorageKey, target interface{}, blockHash types.Hash) (ok bool, err error) {
-	raw, err := s.getStorageRaw(key, &blockHash)
+	return s.GetStorageCtx(context.Background(), key, target, blockHash)
+}
+
+// GetStorageCtx is like GetStorage, aborting the call if ctx is done
+func (s *State) GetStorageCtx(ctx context.Context, key types.StorageKey, target interface{}, blockHash types.Hash) (
+	ok bool, err error) {
+	raw, err := s.getStorageRaw(ctx, key, &blockHash)
 	if err != nil {
 		return false, err
 	}
@@ -39,7 +46,13 @@ func (s *State) GetStorage(key types.StorageKey, target interface{}, blockHash t
 // GetStorageLatest retreives the stored data for the latest block height and decodes them into the provided interface.
 // Ok is true if the value is not empty.
 func (s *State) GetStorageLatest(key types.StorageKey, target interface{}) (ok bool, err error) {
-	raw, err := s.getStorageRaw(key, nil)
+	return s.GetStorageLatestCtx(context.Background(), key, target)
+}
+
+// GetStorageLatestCtx is like GetStorageLatest, aborting the call if ctx is done
+func (s *State) GetStorageLatestCtx(ctx context.Context, key types.StorageKey, target interface{}) (
+	ok bool, err error) {
+	raw, err := s.getStorageRaw(ctx, key, nil)
 	if err != nil {
 		return false, err
 	}
@@ -49,19 +62,65 @@ func (s *State) GetStorageLatest(key types.StorageKey, target interface{}) (ok b
 	return true, types.DecodeFromBytes(*raw, target)
 }
 
+// GetStorageWithDefault retreives the stored data and decodes them into the provided interface like GetStorage. If the
+// key is absent and its storage entry declares a default value in the metadata, the default is decoded instead, as the
+// runtime would do. Ok is false if neither exists.
+func (s *State) GetStorageWithDefault(meta *types.Metadata, key types.StorageKey, target interface{},
+	blockHash types.Hash) (ok bool, err error) {
+	return s.GetStorageWithDefaultCtx(context.Background(), meta, key, target, blockHash)
+}
+
+// GetStorageWithDefaultCtx is like GetStorageWithDefault, aborting the call if ctx is done
+func (s *State) GetStorageWithDefaultCtx(ctx context.Context, meta *types.Metadata, key types.StorageKey,
+	target interface{}, blockHash types.Hash) (ok bool, err error) {
+	raw, err := s.getStorageRaw(ctx, key, &blockHash)
+	if err != nil {
+		return false, err
+	}
+	return meta.DecodeStorageWithDefault(key, *raw, target)
+}
+
+// GetStorageWithDefaultLatest is like GetStorageWithDefault for the latest block height
+func (s *State) GetStorageWithDefaultLatest(meta *types.Metadata, key types.StorageKey, target interface{}) (
+	ok bool, err error) {
+	return s.GetStorageWithDefaultLatestCtx(context.Background(), meta, key, target)
+}
+
+// GetStorageWithDefaultLatestCtx is like GetStorageWithDefaultLatest, aborting the call if ctx is done
+func (s *State) GetStorageWithDefaultLatestCtx(ctx context.Context, meta *types.Metadata, key types.StorageKey,
+	target interface{}) (ok bool, err error) {
+	raw, err := s.getStorageRaw(ctx, key, nil)
+	if err != nil {
+		return false, err
+	}
+	return meta.DecodeStorageWithDefault(key, *raw, target)
+}
+
 // GetStorageRaw retreives the stored data as raw bytes, without decoding them
 func (s *State) GetStorageRaw(key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
-	return s.getStorageRaw(key, &blockHash)
+	return s.GetStorageRawCtx(context.Background(), key, blockHash)
+}
+
+// GetStorageRawCtx is like GetStorageRaw, aborting the call if ctx is done
+func (s *State) GetStorageRawCtx(ctx context.Context, key types.StorageKey, blockHash types.Hash) (
+	*types.StorageDataRaw, error) {
+	return s.getStorageRaw(ctx, key, &blockHash)
 }
 
 // GetStorageRawLatest retreives the stored data for the latest block height as raw bytes, without decoding them
 func (s *State) GetStorageRawLatest(key types.StorageKey) (*types.StorageDataRaw, error) {
-	return s.getStorageRaw(key, nil)
+	return s.GetStorageRawLatestCtx(context.Background(), key)
+}
+
+// GetStorageRawLatestCtx is like GetStorageRawLatest, aborting the call if ctx is done
+func (s *State) GetStorageRawLatestCtx(ctx context.Context, key types.StorageKey) (*types.StorageDataRaw, error) {
+	return s.getStorageRaw(ctx, key, nil)
 }
 
-func (s *State) getStorageRaw(key types.StorageKey, blockHash *types.Hash) (*types.StorageDataRaw, error) {
+func (s *State) getStorageRaw(ctx context.Context, key types.StorageKey, blockHash *types.Hash) (*types.StorageDataRaw,
+	error) {
 	var res string
-	err := client.CallWithBlockHash(s.client, &res, "state_getStorage", blockHash, key.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getStorage", blockHash, key.Hex())
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +141,13 @@ date: 2021-06-07
 */
 
 func (s *State) GetStorageAccountInfo(key types.StorageKey, blockHash types.Hash) (*types.AccountInfo, error) {
-	raw, err := s.getStorageRaw(key, &blockHash)
+	return s.GetStorageAccountInfoCtx(context.Background(), key, blockHash)
+}
+
+// GetStorageAccountInfoCtx is like GetStorageAccountInfo, aborting the call if ctx is done
+func (s *State) GetStorageAccountInfoCtx(ctx context.Context, key types.StorageKey, blockHash types.Hash) (
+	*types.AccountInfo, error) {
+	raw, err := s.getStorageRaw(ctx, key, &blockHash)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,113 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	gethrpc "github.com/JFJun/go-substrate-rpc-client/v3/gethrpc"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// GetStorageBatch retreives the stored data for all keys using batched requests and decodes them into the provided
+// targets, which must be of the same length as keys. oks[i] is true if the value of keys[i] is not empty.
+func (s *State) GetStorageBatch(keys []types.StorageKey, blockHash types.Hash, targets []interface{}) (
+	oks []bool, err error) {
+	return s.GetStorageBatchCtx(context.Background(), keys, blockHash, targets)
+}
+
+// GetStorageBatchCtx is like GetStorageBatch, aborting the calls if ctx is done
+func (s *State) GetStorageBatchCtx(ctx context.Context, keys []types.StorageKey, blockHash types.Hash,
+	targets []interface{}) (oks []bool, err error) {
+	if len(keys) != len(targets) {
+		return nil, fmt.Errorf("got %v keys but %v targets", len(keys), len(targets))
+	}
+
+	raws, err := s.getStorageRawBatch(ctx, keys, &blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	oks = make([]bool, len(raws))
+	for i, raw := range raws {
+		if len(*raw) == 0 {
+			continue
+		}
+		err = types.DecodeFromBytes(*raw, targets[i])
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode storage of key %v: %v", keys[i].Hex(), err)
+		}
+		oks[i] = true
+	}
+	return oks, nil
+}
+
+// GetStorageRawBatch retreives the stored data for all keys as raw bytes using batched requests, without decoding them
+func (s *State) GetStorageRawBatch(keys []types.StorageKey, blockHash types.Hash) ([]*types.StorageDataRaw, error) {
+	return s.GetStorageRawBatchCtx(context.Background(), keys, blockHash)
+}
+
+// GetStorageRawBatchCtx is like GetStorageRawBatch, aborting the calls if ctx is done
+func (s *State) GetStorageRawBatchCtx(ctx context.Context, keys []types.StorageKey, blockHash types.Hash) (
+	[]*types.StorageDataRaw, error) {
+	return s.getStorageRawBatch(ctx, keys, &blockHash)
+}
+
+func (s *State) getStorageRawBatch(ctx context.Context, keys []types.StorageKey, blockHash *types.Hash) (
+	[]*types.StorageDataRaw, error) {
+	var hexHash string
+	if blockHash != nil {
+		var err error
+		hexHash, err = types.Hex(*blockHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([]string, len(keys))
+	batch := make([]gethrpc.BatchElem, len(keys))
+	for i, key := range keys {
+		args := []interface{}{key.Hex()}
+		if blockHash != nil {
+			args = append(args, hexHash)
+		}
+		batch[i] = gethrpc.BatchElem{Method: "state_getStorage", Args: args, Result: &res[i]}
+	}
+
+	err := client.BatchCallInChunks(ctx, s.client, batch, client.DefaultBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	raws := make([]*types.StorageDataRaw, len(keys))
+	for i, elem := range batch {
+		if elem.Error != nil {
+			return nil, fmt.Errorf("unable to get storage of key %v: %v", keys[i].Hex(), elem.Error)
+		}
+
+		bz, err := types.HexDecodeString(res[i])
+		if err != nil {
+			return nil, err
+		}
+
+		data := types.NewStorageDataRaw(bz)
+		raws[i] = &data
+	}
+	return raws, nil
+}
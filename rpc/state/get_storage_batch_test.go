@@ -0,0 +1,66 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_GetStorageBatch(t *testing.T) {
+	// more keys than fit into a single batch, every other key is empty
+	n := 2*client.DefaultBatchSize + 1
+	keys := make([]types.StorageKey, n)
+	decoded := make([]types.U64, n)
+	targets := make([]interface{}, n)
+	for i := range keys {
+		keys[i] = types.MustHexDecodeString(mockSrv.storageKeyHex)
+		if i%2 == 1 {
+			keys[i] = types.MustHexDecodeString(mockSrv.storageKeyHexEmpty)
+		}
+		targets[i] = &decoded[i]
+	}
+
+	oks, err := state.GetStorageBatch(keys, mockSrv.blockHashLatest, targets)
+	assert.NoError(t, err)
+	assert.Len(t, oks, n)
+	for i := range oks {
+		assert.Equal(t, i%2 == 0, oks[i])
+		if i%2 == 0 {
+			assert.Equal(t, types.U64(0x5d892db8), decoded[i])
+		}
+	}
+}
+
+func TestState_GetStorageBatch_TargetMismatch(t *testing.T) {
+	_, err := state.GetStorageBatch([]types.StorageKey{{0xab}}, mockSrv.blockHashLatest, nil)
+	assert.EqualError(t, err, "got 1 keys but 0 targets")
+}
+
+func TestState_GetStorageRawBatch(t *testing.T) {
+	keys := []types.StorageKey{
+		types.MustHexDecodeString(mockSrv.storageKeyHex),
+		types.MustHexDecodeString(mockSrv.storageKeyHexEmpty),
+	}
+	data, err := state.GetStorageRawBatch(keys, mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+	assert.Equal(t, mockSrv.storageDataHex, data[0].Hex())
+	assert.Len(t, *data[1], 0)
+}
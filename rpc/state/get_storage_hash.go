@@ -17,23 +17,34 @@
 package state
 
 import (
+	"context"
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // GetStorageHash retreives the storage hash for the given key
 func (s *State) GetStorageHash(key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
-	return s.getStorageHash(key, &blockHash)
+	return s.GetStorageHashCtx(context.Background(), key, blockHash)
+}
+
+// GetStorageHashCtx is like GetStorageHash, aborting the call if ctx is done
+func (s *State) GetStorageHashCtx(ctx context.Context, key types.StorageKey, blockHash types.Hash) (types.Hash, error) {
+	return s.getStorageHash(ctx, key, &blockHash)
 }
 
 // GetStorageHashLatest retreives the storage hash for the given key for the latest block height
 func (s *State) GetStorageHashLatest(key types.StorageKey) (types.Hash, error) {
-	return s.getStorageHash(key, nil)
+	return s.GetStorageHashLatestCtx(context.Background(), key)
+}
+
+// GetStorageHashLatestCtx is like GetStorageHashLatest, aborting the call if ctx is done
+func (s *State) GetStorageHashLatestCtx(ctx context.Context, key types.StorageKey) (types.Hash, error) {
+	return s.getStorageHash(ctx, key, nil)
 }
 
-func (s *State) getStorageHash(key types.StorageKey, blockHash *types.Hash) (types.Hash, error) {
+func (s *State) getStorageHash(ctx context.Context, key types.StorageKey, blockHash *types.Hash) (types.Hash, error) {
 	var res string
-	err := client.CallWithBlockHash(s.client, &res, "state_getStorageHash", blockHash, key.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getStorageHash", blockHash, key.Hex())
 	if err != nil {
 		return types.Hash{}, err
 	}
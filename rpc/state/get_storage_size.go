@@ -17,23 +17,34 @@
 package state
 
 import (
+	"context"
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // GetStorageSize retreives the storage size for the given key
 func (s *State) GetStorageSize(key types.StorageKey, blockHash types.Hash) (types.U64, error) {
-	return s.getStorageSize(key, &blockHash)
+	return s.GetStorageSizeCtx(context.Background(), key, blockHash)
+}
+
+// GetStorageSizeCtx is like GetStorageSize, aborting the call if ctx is done
+func (s *State) GetStorageSizeCtx(ctx context.Context, key types.StorageKey, blockHash types.Hash) (types.U64, error) {
+	return s.getStorageSize(ctx, key, &blockHash)
 }
 
 // GetStorageSizeLatest retreives the storage size for the given key for the latest block height
 func (s *State) GetStorageSizeLatest(key types.StorageKey) (types.U64, error) {
-	return s.getStorageSize(key, nil)
+	return s.GetStorageSizeLatestCtx(context.Background(), key)
+}
+
+// GetStorageSizeLatestCtx is like GetStorageSizeLatest, aborting the call if ctx is done
+func (s *State) GetStorageSizeLatestCtx(ctx context.Context, key types.StorageKey) (types.U64, error) {
+	return s.getStorageSize(ctx, key, nil)
 }
 
-func (s *State) getStorageSize(key types.StorageKey, blockHash *types.Hash) (types.U64, error) {
+func (s *State) getStorageSize(ctx context.Context, key types.StorageKey, blockHash *types.Hash) (types.U64, error) {
 	var res types.U64
-	err := client.CallWithBlockHash(s.client, &res, "state_getStorageSize", blockHash, key.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_getStorageSize", blockHash, key.Hex())
 	if err != nil {
 		return 0, err
 	}
@@ -57,3 +57,37 @@ func TestState_GetStorageRaw(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, mockSrv.storageDataHex, data.Hex())
 }
+
+func TestState_GetStorageWithDefault(t *testing.T) {
+	var meta types.Metadata
+	err := types.DecodeFromHexString(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	// System.Account is declared with a zero default, the mock does not know the key
+	key, err := types.CreateStorageKey(&meta, "System", "Account", types.MustHexDecodeString(mockSrv.storageKeyHex))
+	assert.NoError(t, err)
+	decoded := types.U64(1)
+	ok, err := state.GetStorageWithDefault(&meta, key, &decoded, mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.U64(0), decoded)
+
+	// Staking.Bonded has no default
+	key, err = types.CreateStorageKey(&meta, "Staking", "Bonded", types.MustHexDecodeString(mockSrv.storageKeyHex))
+	assert.NoError(t, err)
+	ok, err = state.GetStorageWithDefaultLatest(&meta, key, &decoded)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestState_GetStorageWithDefault_Present(t *testing.T) {
+	var meta types.Metadata
+	err := types.DecodeFromHexString(types.MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	var decoded types.U64
+	ok, err := state.GetStorageWithDefaultLatest(&meta, types.MustHexDecodeString(mockSrv.storageKeyHex), &decoded)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.U64(0x5d892db8), decoded)
+}
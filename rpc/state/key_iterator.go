@@ -0,0 +1,145 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// DefaultKeyIteratorPageSize is the number of keys a KeyIterator fetches per page if no page size is given
+const DefaultKeyIteratorPageSize = 1000
+
+// KeyIterator walks all storage keys with a given prefix at a fixed block, fetching them lazily page by page via
+// state_getKeysPaged, so that large storage maps can be scanned with bounded memory. If enabled with WithValues, the
+// values of each page are fetched in batched requests as well.
+//
+//	it := api.RPC.State.NewKeyIterator(prefix, 0, blockHash).WithValues()
+//	for it.Next() {
+//		key, value := it.Key(), it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type KeyIterator struct {
+	state      *State
+	ctx        context.Context
+	prefix     types.StorageKey
+	pageSize   uint32
+	blockHash  types.Hash
+	withValues bool
+
+	keys   []types.StorageKey
+	values []*types.StorageDataRaw
+	pos    int
+	done   bool
+	err    error
+}
+
+// NewKeyIterator returns a KeyIterator over all keys with the given prefix at the given block. A pageSize of 0 means
+// DefaultKeyIteratorPageSize.
+func (s *State) NewKeyIterator(prefix types.StorageKey, pageSize uint32, blockHash types.Hash) *KeyIterator {
+	return s.NewKeyIteratorCtx(context.Background(), prefix, pageSize, blockHash)
+}
+
+// NewKeyIteratorCtx is like NewKeyIterator, aborting the iteration if ctx is done
+func (s *State) NewKeyIteratorCtx(ctx context.Context, prefix types.StorageKey, pageSize uint32,
+	blockHash types.Hash) *KeyIterator {
+	if pageSize == 0 {
+		pageSize = DefaultKeyIteratorPageSize
+	}
+	return &KeyIterator{
+		state:     s,
+		ctx:       ctx,
+		prefix:    prefix,
+		pageSize:  pageSize,
+		blockHash: blockHash,
+		pos:       -1,
+	}
+}
+
+// WithValues makes the iterator fetch the value of every key, available through Value. Must be called before the
+// first call to Next.
+func (it *KeyIterator) WithValues() *KeyIterator {
+	it.withValues = true
+	return it
+}
+
+// Next advances the iterator to the next key, fetching the next page if required. It returns false when all keys
+// have been visited or an error occurred, check Err to distinguish both cases.
+func (it *KeyIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.pos+1 < len(it.keys) {
+		it.pos++
+		return true
+	}
+
+	if it.done {
+		return false
+	}
+
+	var startKey types.StorageKey
+	if len(it.keys) > 0 {
+		startKey = it.keys[len(it.keys)-1]
+	}
+
+	keys, err := it.state.getKeysPaged(it.ctx, it.prefix, it.pageSize, startKey, &it.blockHash)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	var values []*types.StorageDataRaw
+	if it.withValues && len(keys) > 0 {
+		values, err = it.state.getStorageRawBatch(it.ctx, keys, &it.blockHash)
+		if err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.keys, it.values, it.pos = keys, values, 0
+	it.done = uint32(len(keys)) < it.pageSize
+
+	return len(keys) > 0
+}
+
+// Key returns the current key
+func (it *KeyIterator) Key() types.StorageKey {
+	if it.pos < 0 || it.pos >= len(it.keys) {
+		return nil
+	}
+	return it.keys[it.pos]
+}
+
+// Value returns the value of the current key, or nil if the iterator was not created with WithValues
+func (it *KeyIterator) Value() *types.StorageDataRaw {
+	if it.pos < 0 || it.pos >= len(it.values) {
+		return nil
+	}
+	return it.values[it.pos]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *KeyIterator) Err() error {
+	return it.err
+}
@@ -17,6 +17,7 @@
 package state
 
 import (
+	"context"
 	"github.com/JFJun/go-substrate-rpc-client/v3/client"
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
@@ -24,15 +25,27 @@ import (
 // QueryStorage queries historical storage entries (by key) starting from a start block until an end block
 func (s *State) QueryStorage(keys []types.StorageKey, startBlock types.Hash, block types.Hash) (
 	[]types.StorageChangeSet, error) {
-	return s.queryStorage(keys, startBlock, &block)
+	return s.QueryStorageCtx(context.Background(), keys, startBlock, block)
+}
+
+// QueryStorageCtx is like QueryStorage, aborting the call if ctx is done
+func (s *State) QueryStorageCtx(ctx context.Context, keys []types.StorageKey, startBlock types.Hash, block types.Hash) (
+	[]types.StorageChangeSet, error) {
+	return s.queryStorage(ctx, keys, startBlock, &block)
 }
 
 // QueryStorageLatest queries historical storage entries (by key) starting from a start block until the latest block
 func (s *State) QueryStorageLatest(keys []types.StorageKey, startBlock types.Hash) ([]types.StorageChangeSet, error) {
-	return s.queryStorage(keys, startBlock, nil)
+	return s.QueryStorageLatestCtx(context.Background(), keys, startBlock)
+}
+
+// QueryStorageLatestCtx is like QueryStorageLatest, aborting the call if ctx is done
+func (s *State) QueryStorageLatestCtx(ctx context.Context, keys []types.StorageKey, startBlock types.Hash) (
+	[]types.StorageChangeSet, error) {
+	return s.queryStorage(ctx, keys, startBlock, nil)
 }
 
-func (s *State) queryStorage(keys []types.StorageKey, startBlock types.Hash, block *types.Hash) (
+func (s *State) queryStorage(ctx context.Context, keys []types.StorageKey, startBlock types.Hash, block *types.Hash) (
 	[]types.StorageChangeSet, error) {
 	hexKeys := make([]string, len(keys))
 	for i, key := range keys {
@@ -40,7 +53,7 @@ func (s *State) queryStorage(keys []types.StorageKey, startBlock types.Hash, blo
 	}
 
 	var res []types.StorageChangeSet
-	err := client.CallWithBlockHash(s.client, &res, "state_queryStorage", block, hexKeys, startBlock.Hex())
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_queryStorage", block, hexKeys, startBlock.Hex())
 	if err != nil {
 		return nil, err
 	}
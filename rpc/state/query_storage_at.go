@@ -0,0 +1,118 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// QueryStorageAt queries the storage entries of all keys at the given block in a single request
+func (s *State) QueryStorageAt(keys []types.StorageKey, blockHash types.Hash) ([]types.StorageChangeSet, error) {
+	return s.QueryStorageAtCtx(context.Background(), keys, blockHash)
+}
+
+// QueryStorageAtCtx is like QueryStorageAt, aborting the call if ctx is done
+func (s *State) QueryStorageAtCtx(ctx context.Context, keys []types.StorageKey, blockHash types.Hash) (
+	[]types.StorageChangeSet, error) {
+	return s.queryStorageAt(ctx, keys, &blockHash)
+}
+
+// QueryStorageAtLatest queries the storage entries of all keys at the latest block height in a single request
+func (s *State) QueryStorageAtLatest(keys []types.StorageKey) ([]types.StorageChangeSet, error) {
+	return s.QueryStorageAtLatestCtx(context.Background(), keys)
+}
+
+// QueryStorageAtLatestCtx is like QueryStorageAtLatest, aborting the call if ctx is done
+func (s *State) QueryStorageAtLatestCtx(ctx context.Context, keys []types.StorageKey) (
+	[]types.StorageChangeSet, error) {
+	return s.queryStorageAt(ctx, keys, nil)
+}
+
+// GetStorageMulti retreives the stored data of all keys at the given block in a single state_queryStorageAt request
+// and decodes them into the provided targets, which must be of the same length as keys. oks[i] is true if the value
+// of keys[i] is not empty.
+func (s *State) GetStorageMulti(keys []types.StorageKey, blockHash types.Hash, targets []interface{}) (
+	oks []bool, err error) {
+	return s.GetStorageMultiCtx(context.Background(), keys, blockHash, targets)
+}
+
+// GetStorageMultiCtx is like GetStorageMulti, aborting the call if ctx is done
+func (s *State) GetStorageMultiCtx(ctx context.Context, keys []types.StorageKey, blockHash types.Hash,
+	targets []interface{}) (oks []bool, err error) {
+	if len(keys) != len(targets) {
+		return nil, fmt.Errorf("got %v keys but %v targets", len(keys), len(targets))
+	}
+
+	sets, err := s.queryStorageAt(ctx, keys, &blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeStorageChanges(sets, keys, targets)
+}
+
+// DecodeStorageChanges decodes the data of the given keys in the storage change sets into the provided targets, which
+// must be of the same length as keys. If a key changed in more than one change set, the data of the last one is used.
+// oks[i] is true if the data of keys[i] is not empty.
+func DecodeStorageChanges(sets []types.StorageChangeSet, keys []types.StorageKey, targets []interface{}) (
+	oks []bool, err error) {
+	if len(keys) != len(targets) {
+		return nil, fmt.Errorf("got %v keys but %v targets", len(keys), len(targets))
+	}
+
+	data := make(map[string]types.KeyValueOption)
+	for _, set := range sets {
+		for _, change := range set.Changes {
+			data[change.StorageKey.Hex()] = change
+		}
+	}
+
+	oks = make([]bool, len(keys))
+	for i, key := range keys {
+		change, ok := data[key.Hex()]
+		if !ok || !change.HasStorageData || len(change.StorageData) == 0 {
+			continue
+		}
+
+		err = types.DecodeFromBytes(change.StorageData, targets[i])
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode storage of key %v: %v", key.Hex(), err)
+		}
+		oks[i] = true
+	}
+	return oks, nil
+}
+
+func (s *State) queryStorageAt(ctx context.Context, keys []types.StorageKey, blockHash *types.Hash) (
+	[]types.StorageChangeSet, error) {
+	hexKeys := make([]string, len(keys))
+	for i, key := range keys {
+		hexKeys[i] = key.Hex()
+	}
+
+	var res []types.StorageChangeSet
+	err := client.CallWithBlockHashContext(ctx, s.client, &res, "state_queryStorageAt", blockHash, hexKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,65 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestState_QueryStorageAt(t *testing.T) {
+	key := types.NewStorageKey(types.MustHexDecodeString(mockSrv.storageKeyHex))
+	data, err := state.QueryStorageAt([]types.StorageKey{key}, mockSrv.blockHashLatest)
+	assert.NoError(t, err)
+	assert.Equal(t, []types.StorageChangeSet{{
+		Block: mockSrv.blockHashLatest,
+		Changes: []types.KeyValueOption{{
+			StorageKey:     key,
+			HasStorageData: true,
+			StorageData:    types.MustHexDecodeString(mockSrv.storageDataHex),
+		}},
+	}}, data)
+}
+
+func TestState_GetStorageMulti(t *testing.T) {
+	keys := []types.StorageKey{
+		types.MustHexDecodeString(mockSrv.storageKeyHex),
+		types.MustHexDecodeString(mockSrv.storageKeyHexEmpty),
+	}
+	var value, empty types.U64
+
+	oks, err := state.GetStorageMulti(keys, mockSrv.blockHashLatest, []interface{}{&value, &empty})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, oks)
+	assert.Equal(t, types.U64(0x5d892db8), value)
+}
+
+func TestDecodeStorageChanges_LastChangeWins(t *testing.T) {
+	key := types.StorageKey{0x01}
+	sets := []types.StorageChangeSet{
+		{Changes: []types.KeyValueOption{{StorageKey: key, HasStorageData: true, StorageData: []byte{0x01}}}},
+		{Changes: []types.KeyValueOption{{StorageKey: key, HasStorageData: true, StorageData: []byte{0x02}}}},
+	}
+	var value types.U8
+
+	oks, err := DecodeStorageChanges(sets, []types.StorageKey{key}, []interface{}{&value})
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true}, oks)
+	assert.Equal(t, types.U8(2), value)
+}
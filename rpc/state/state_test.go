@@ -17,6 +17,7 @@
 package state
 
 import (
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -54,6 +55,7 @@ type MockSrv struct {
 	runtimeVersion           types.RuntimeVersion
 	storageKeyHex            string
 	storageKeyHexEmpty       string
+	pagedKeys                int
 	storageChangeSets        []types.StorageChangeSet
 	storageDataHex           string
 	storageSize              types.U64
@@ -81,6 +83,26 @@ func (s *MockSrv) GetKeys(key string, hash *string) []string {
 	return []string{mockSrv.storageKeyHex}
 }
 
+// GetKeysPaged serves the storage key and mockSrv.pagedKeys keys below it
+func (s *MockSrv) GetKeysPaged(prefix string, count uint32, startKey *string, hash *string) []string {
+	all := []string{mockSrv.storageKeyHex}
+	for i := 0; i < mockSrv.pagedKeys; i++ {
+		all = append(all, fmt.Sprintf("%v%02x", mockSrv.storageKeyHex, i))
+	}
+
+	res := []string{}
+	for _, key := range all {
+		if !strings.HasPrefix(key, prefix) || (startKey != nil && key <= *startKey) {
+			continue
+		}
+		if uint32(len(res)) == count {
+			break
+		}
+		res = append(res, key)
+	}
+	return res
+}
+
 func (s *MockSrv) GetStorage(key string, hash *string) string {
 	if key != s.storageKeyHex {
 		return ""
@@ -150,6 +172,19 @@ func (s *MockSrv) QueryStorage(keys []string, startBlock string, block *string)
 	return mockSrv.storageChangeSets
 }
 
+func (s *MockSrv) QueryStorageAt(keys []string, block *string) []types.StorageChangeSet {
+	set := types.StorageChangeSet{Block: s.blockHashLatest}
+	for _, key := range keys {
+		change := types.KeyValueOption{StorageKey: types.MustHexDecodeString(key)}
+		if key == s.storageKeyHex {
+			change.HasStorageData = true
+			change.StorageData = types.MustHexDecodeString(s.storageDataHex)
+		}
+		set.Changes = append(set.Changes, change)
+	}
+	return []types.StorageChangeSet{set}
+}
+
 // func (s *MockSrv) SubscribeStorage(args []string) {
 // 	fmt.Println("Hit")
 // }
@@ -170,6 +205,7 @@ var mockSrv = MockSrv{
 	runtimeVersion:           types.RuntimeVersion{APIs: []types.RuntimeVersionAPI{{APIID: "0xdf6acb689907609b", Version: 0x2}, {APIID: "0x37e397fc7c91f5e4", Version: 0x1}, {APIID: "0x40fe3ad401f8959a", Version: 0x3}, {APIID: "0xd2bc9897eed08f15", Version: 0x1}, {APIID: "0xf78b278be53f454c", Version: 0x1}, {APIID: "0xed99c5acb25eedf5", Version: 0x2}, {APIID: "0xdd718d5cc53262d4", Version: 0x1}, {APIID: "0x7801759919ee83e5", Version: 0x1}}, AuthoringVersion: 0xa, ImplName: "substrate-node", ImplVersion: 0x3e, SpecName: "node", SpecVersion: 0x3c}, //nolint:lll
 	storageKeyHex:            "0x0e4944cfd98d6f4cc374d16f5a4e3f9c",
 	storageKeyHexEmpty:       "0xeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee",
+	pagedKeys:                24,
 	storageChangeSets:        []types.StorageChangeSet{{Block: types.Hash{0xdd, 0x18, 0x16, 0xb6, 0xf6, 0x88, 0x9f, 0x46, 0xe2, 0x3b, 0xd, 0x67, 0x50, 0xbc, 0x44, 0x1a, 0xf9, 0xda, 0xd0, 0xfd, 0xa8, 0xba, 0xe9, 0x6, 0x77, 0xc1, 0x70, 0x8d, 0x1, 0x3, 0x5f, 0xbe}, Changes: []types.KeyValueOption{{StorageKey: types.StorageKey{0xe, 0x49, 0x44, 0xcf, 0xd9, 0x8d, 0x6f, 0x4c, 0xc3, 0x74, 0xd1, 0x6f, 0x5a, 0x4e, 0x3f, 0x9c}, HasStorageData: true, StorageData: types.StorageDataRaw{0x88, 0x2, 0x66, 0x9f, 0x6e, 0x1, 0x0, 0x0}}}}, {Block: types.Hash{0x82, 0x14, 0xa1, 0x80, 0x8b, 0xd6, 0xb0, 0x46, 0xc8, 0x77, 0xa6, 0x4f, 0xce, 0xad, 0xb4, 0xa2, 0xa7, 0x3a, 0x65, 0x76, 0x9f, 0x61, 0x4, 0xc0, 0x20, 0xd7, 0x59, 0xad, 0x8f, 0x61, 0xc0, 0xd8}, Changes: []types.KeyValueOption{{StorageKey: types.StorageKey{0xe, 0x49, 0x44, 0xcf, 0xd9, 0x8d, 0x6f, 0x4c, 0xc3, 0x74, 0xd1, 0x6f, 0x5a, 0x4e, 0x3f, 0x9c}, HasStorageData: true, StorageData: types.StorageDataRaw{0x40, 0xe, 0x66, 0x9f, 0x6e, 0x1, 0x0, 0x0}}}}}, //nolint:lll
 	storageDataHex:           "0xb82d895d00000000",
 	storageSize:              926778,
@@ -62,11 +62,16 @@ func (s *RuntimeVersionSubscription) Unsubscribe() {
 
 // SubscribeRuntimeVersion subscribes the runtime version, returning a subscription that will
 // receive server notifications containing the RuntimeVersion.
-func (s *State) SubscribeRuntimeVersion() (
-	*RuntimeVersionSubscription, error) {
+func (s *State) SubscribeRuntimeVersion() (*RuntimeVersionSubscription, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
 	defer cancel()
 
+	return s.SubscribeRuntimeVersionCtx(ctx)
+}
+
+// SubscribeRuntimeVersionCtx subscribes the runtime version like SubscribeRuntimeVersion, using ctx instead of the
+// default subscribe timeout to establish the subscription
+func (s *State) SubscribeRuntimeVersionCtx(ctx context.Context) (*RuntimeVersionSubscription, error) {
 	c := make(chan types.RuntimeVersion)
 
 	sub, err := s.client.Subscribe(ctx, "state", "subscribeRuntimeVersion", "unsubscribeRuntimeVersion",
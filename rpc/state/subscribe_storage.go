@@ -66,11 +66,16 @@ func (s *StorageSubscription) Unsubscribe() {
 // Slow subscribers will be dropped eventually. Client buffers up to 20000 notifications before considering the
 // subscriber dead. The subscription Err channel will receive ErrSubscriptionQueueOverflow. Use a sufficiently
 // large buffer on the channel or ensure that the channel usually has at least one reader to prevent this issue.
-func (s *State) SubscribeStorageRaw(keys []types.StorageKey) (
-	*StorageSubscription, error) {
+func (s *State) SubscribeStorageRaw(keys []types.StorageKey) (*StorageSubscription, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), config.Default().SubscribeTimeout)
 	defer cancel()
 
+	return s.SubscribeStorageRawCtx(ctx, keys)
+}
+
+// SubscribeStorageRawCtx subscribes the storage like SubscribeStorageRaw, using ctx instead of the default subscribe
+// timeout to establish the subscription
+func (s *State) SubscribeStorageRawCtx(ctx context.Context, keys []types.StorageKey) (*StorageSubscription, error) {
 	c := make(chan types.StorageChangeSet)
 
 	keyss := make([]string, len(keys))
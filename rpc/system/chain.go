@@ -17,12 +17,19 @@
 package system
 
 import (
+	"context"
+
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // Chain retrieves the chain
 func (c *System) Chain() (types.Text, error) {
+	return c.ChainCtx(context.Background())
+}
+
+// ChainCtx retrieves the chain, aborting if ctx is done
+func (c *System) ChainCtx(ctx context.Context) (types.Text, error) {
 	var t types.Text
-	err := c.client.Call(&t, "system_chain")
+	err := c.client.CallContext(ctx, &t, "system_chain")
 	return t, err
 }
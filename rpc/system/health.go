@@ -17,12 +17,19 @@
 package system
 
 import (
+	"context"
+
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // Health retrieves the health status of the connected node
 func (c *System) Health() (types.Health, error) {
+	return c.HealthCtx(context.Background())
+}
+
+// HealthCtx retrieves the health status of the connected node, aborting if ctx is done
+func (c *System) HealthCtx(ctx context.Context) (types.Health, error) {
 	var h types.Health
-	err := c.client.Call(&h, "system_health")
+	err := c.client.CallContext(ctx, &h, "system_health")
 	return h, err
 }
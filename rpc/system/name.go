@@ -17,12 +17,19 @@
 package system
 
 import (
+	"context"
+
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // Name retrieves the node name
 func (c *System) Name() (types.Text, error) {
+	return c.NameCtx(context.Background())
+}
+
+// NameCtx retrieves the node name, aborting if ctx is done
+func (c *System) NameCtx(ctx context.Context) (types.Text, error) {
 	var t types.Text
-	err := c.client.Call(&t, "system_name")
+	err := c.client.CallContext(ctx, &t, "system_name")
 	return t, err
 }
@@ -17,12 +17,19 @@
 package system
 
 import (
+	"context"
+
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // NetworkState retrieves the current state of the network
 func (c *System) NetworkState() (types.NetworkState, error) {
+	return c.NetworkStateCtx(context.Background())
+}
+
+// NetworkStateCtx retrieves the current state of the network, aborting if ctx is done
+func (c *System) NetworkStateCtx(ctx context.Context) (types.NetworkState, error) {
 	var n types.NetworkState
-	err := c.client.Call(&n, "system_networkState")
+	err := c.client.CallContext(ctx, &n, "system_networkState")
 	return n, err
 }
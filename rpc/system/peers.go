@@ -17,12 +17,19 @@
 package system
 
 import (
+	"context"
+
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // Peers retrieves the currently connected peers
 func (c *System) Peers() ([]types.PeerInfo, error) {
+	return c.PeersCtx(context.Background())
+}
+
+// PeersCtx retrieves the currently connected peers, aborting if ctx is done
+func (c *System) PeersCtx(ctx context.Context) ([]types.PeerInfo, error) {
 	var p []types.PeerInfo
-	err := c.client.Call(&p, "system_peers")
+	err := c.client.CallContext(ctx, &p, "system_peers")
 	return p, err
 }
@@ -17,12 +17,20 @@
 package system
 
 import (
+	"context"
+
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // Properties retrieves a custom set of properties as a JSON object, defined in the chain spec
 func (c *System) Properties() (types.ChainProperties, error) {
+	return c.PropertiesCtx(context.Background())
+}
+
+// PropertiesCtx retrieves a custom set of properties as a JSON object, defined in the chain spec, aborting if ctx is
+// done
+func (c *System) PropertiesCtx(ctx context.Context) (types.ChainProperties, error) {
 	var p types.ChainProperties
-	err := c.client.Call(&p, "system_properties")
+	err := c.client.CallContext(ctx, &p, "system_properties")
 	return p, err
 }
@@ -93,7 +93,7 @@ var mockSrv = MockSrv{
 	networkState: types.NetworkState{PeerID: "my-peer-id"},
 	peers: []types.PeerInfo{{PeerID: "another-peer-id", Roles: "Role", ProtocolVersion: 42,
 		BestHash: types.NewHash(types.MustHexDecodeString("0xabcd")), BestNumber: 420}},
-	properties: types.ChainProperties{IsTokenDecimals: true, AsTokenDecimals: 18,
+	properties: types.ChainProperties{IsSS58Format: true, AsSS58Format: 1284, IsTokenDecimals: true, AsTokenDecimals: 18,
 		IsTokenSymbol: true, AsTokenSymbol: "GSRPCCOIN"},
 	version: "My version",
 }
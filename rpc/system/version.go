@@ -17,12 +17,19 @@
 package system
 
 import (
+	"context"
+
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
 )
 
 // Version retrieves the version of the node
 func (c *System) Version() (types.Text, error) {
+	return c.VersionCtx(context.Background())
+}
+
+// VersionCtx retrieves the version of the node, aborting if ctx is done
+func (c *System) VersionCtx(ctx context.Context) (types.Text, error) {
 	var t types.Text
-	err := c.client.Call(&t, "system_version")
+	err := c.client.CallContext(ctx, &t, "system_version")
 	return t, err
 }
@@ -17,29 +17,101 @@
 package signature
 
 import (
+	stded25519 "crypto/ed25519"
 	"errors"
 	"fmt"
 	"os"
 	"strconv"
 
+	schnorrkel "github.com/ChainSafe/go-schnorrkel"
+	secp256k1 "github.com/ethereum/go-ethereum/crypto"
 	"github.com/vedhavyas/go-subkey"
+	"github.com/vedhavyas/go-subkey/ecdsa"
+	"github.com/vedhavyas/go-subkey/ed25519"
 	"github.com/vedhavyas/go-subkey/sr25519"
 	"golang.org/x/crypto/blake2b"
 )
 
+// SignatureType identifies the signature scheme of a Signer or KeyringPair. The zero value is sr25519, the default
+// scheme of substrate accounts.
+type SignatureType uint8
+
+const (
+	SignatureTypeSr25519 SignatureType = iota
+	SignatureTypeEd25519
+	SignatureTypeEcdsa
+)
+
+func (t SignatureType) String() string {
+	switch t {
+	case SignatureTypeSr25519:
+		return "sr25519"
+	case SignatureTypeEd25519:
+		return "ed25519"
+	case SignatureTypeEcdsa:
+		return "ecdsa"
+	default:
+		return fmt.Sprintf("SignatureType(%d)", uint8(t))
+	}
+}
+
+// scheme returns the subkey scheme for the signature type
+func (t SignatureType) scheme() (subkey.Scheme, error) {
+	switch t {
+	case SignatureTypeSr25519:
+		return sr25519.Scheme{}, nil
+	case SignatureTypeEd25519:
+		return ed25519.Scheme{}, nil
+	case SignatureTypeEcdsa:
+		return ecdsa.Scheme{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature type %v", t)
+	}
+}
+
+// signatureLength returns the length of signatures of the signature type
+func (t SignatureType) signatureLength() int {
+	if t == SignatureTypeEcdsa {
+		return 65
+	}
+	return 64
+}
+
+// Signer signs payloads without exposing its private key, which allows keys to live in an HSM, a remote signing
+// service or a mock in tests. KeyringPair is the default implementation.
+type Signer interface {
+	// Public returns the public key of the signer
+	Public() []byte
+	// Sign signs the given message
+	Sign(msg []byte) ([]byte, error)
+	// SignatureType returns the signature scheme used by Sign
+	SignatureType() SignatureType
+}
+
 type KeyringPair struct {
 	// URI is the derivation path for the private key in subkey
 	URI string
 	// Address is an SS58 address
 	Address string
-	// PublicKey
+	// PublicKey, compressed (33 bytes) for ecdsa
 	PublicKey []byte
+	// Type is the signature scheme of the key, defaults to sr25519
+	Type SignatureType
 }
 
 // KeyringPairFromSecret creates KeyPair based on seed/phrase and network
 // Leave network empty for default behavior
 func KeyringPairFromSecret(seedOrPhrase string, network uint8) (KeyringPair, error) {
-	scheme := sr25519.Scheme{}
+	return KeyringPairFromSecretWithType(seedOrPhrase, network, SignatureTypeSr25519)
+}
+
+// KeyringPairFromSecretWithType creates KeyPair based on seed/phrase and network using the given signature scheme
+func KeyringPairFromSecretWithType(seedOrPhrase string, network uint8, t SignatureType) (KeyringPair, error) {
+	scheme, err := t.scheme()
+	if err != nil {
+		return KeyringPair{}, err
+	}
+
 	kyr, err := subkey.DeriveKeyPair(scheme, seedOrPhrase)
 	if err != nil {
 		return KeyringPair{}, err
@@ -56,9 +128,40 @@ func KeyringPairFromSecret(seedOrPhrase string, network uint8) (KeyringPair, err
 		URI:       seedOrPhrase,
 		Address:   ss58Address,
 		PublicKey: pk,
+		Type:      t,
 	}, nil
 }
 
+// AccountID returns the account id of the public key for the given signature scheme. For ecdsa that is the blake2_256
+// hash of the compressed public key, for the other schemes the public key itself.
+func AccountID(publicKey []byte, t SignatureType) []byte {
+	if t == SignatureTypeEcdsa {
+		h := blake2b.Sum256(publicKey)
+		return h[:]
+	}
+	return publicKey
+}
+
+// Public returns the public key of the keyring pair
+func (k KeyringPair) Public() []byte {
+	return k.PublicKey
+}
+
+// AccountID returns the account id of the keyring pair
+func (k KeyringPair) AccountID() []byte {
+	return AccountID(k.PublicKey, k.Type)
+}
+
+// Sign signs msg with the private key under the derivation path of the keyring pair
+func (k KeyringPair) Sign(msg []byte) ([]byte, error) {
+	return SignWithType(msg, k.URI, k.Type)
+}
+
+// SignatureType returns the signature scheme of the keyring pair
+func (k KeyringPair) SignatureType() SignatureType {
+	return k.Type
+}
+
 var TestKeyringPairAlice = KeyringPair{
 	URI:       "//Alice",
 	PublicKey: []byte{0xd4, 0x35, 0x93, 0xc7, 0x15, 0xfd, 0xd3, 0x1c, 0x61, 0x14, 0x1a, 0xbd, 0x4, 0xa9, 0x9f, 0xd6, 0x82, 0x2c, 0x85, 0x58, 0x85, 0x4c, 0xcd, 0xe3, 0x9a, 0x56, 0x84, 0xe7, 0xa5, 0x6d, 0xa2, 0x7d}, //nolint:lll
@@ -68,13 +171,23 @@ var TestKeyringPairAlice = KeyringPair{
 // Sign signs data with the private key under the given derivation path, returning the signature. Requires the subkey
 // command to be in path
 func Sign(data []byte, privateKeyURI string) ([]byte, error) {
+	return SignWithType(data, privateKeyURI, SignatureTypeSr25519)
+}
+
+// SignWithType signs data with the private key of the given signature scheme under the given derivation path,
+// returning the signature
+func SignWithType(data []byte, privateKeyURI string, t SignatureType) ([]byte, error) {
 	// if data is longer than 256 bytes, hash it first
 	if len(data) > 256 {
 		h := blake2b.Sum256(data)
 		data = h[:]
 	}
 
-	scheme := sr25519.Scheme{}
+	scheme, err := t.scheme()
+	if err != nil {
+		return nil, err
+	}
+
 	kyr, err := subkey.DeriveKeyPair(scheme, privateKeyURI)
 	if err != nil {
 		return nil, err
@@ -91,19 +204,29 @@ func Sign(data []byte, privateKeyURI string) ([]byte, error) {
 // Verify verifies data using the provided signature and the key under the derivation path. Requires the subkey
 // command to be in path
 func Verify(data []byte, sig []byte, privateKeyURI string) (bool, error) {
+	return VerifyWithType(data, sig, privateKeyURI, SignatureTypeSr25519)
+}
+
+// VerifyWithType verifies data using the provided signature and the key of the given signature scheme under the
+// derivation path
+func VerifyWithType(data []byte, sig []byte, privateKeyURI string, t SignatureType) (bool, error) {
 	// if data is longer than 256 bytes, hash it first
 	if len(data) > 256 {
 		h := blake2b.Sum256(data)
 		data = h[:]
 	}
 
-	scheme := sr25519.Scheme{}
+	scheme, err := t.scheme()
+	if err != nil {
+		return false, err
+	}
+
 	kyr, err := subkey.DeriveKeyPair(scheme, privateKeyURI)
 	if err != nil {
 		return false, err
 	}
 
-	if len(sig) != 64 {
+	if len(sig) != t.signatureLength() {
 		return false, errors.New("wrong signature length")
 	}
 
@@ -112,6 +235,68 @@ func Verify(data []byte, sig []byte, privateKeyURI string) (bool, error) {
 	return v, nil
 }
 
+// VerifyWithPublicKey verifies data using the provided signature and public key of the given signature scheme, without
+// requiring the private key. For ecdsa, the public key must be compressed (33 bytes).
+func VerifyWithPublicKey(data []byte, sig []byte, publicKey []byte, t SignatureType) (bool, error) {
+	// if data is longer than 256 bytes, hash it first
+	if len(data) > 256 {
+		h := blake2b.Sum256(data)
+		data = h[:]
+	}
+
+	if len(sig) != t.signatureLength() {
+		return false, errors.New("wrong signature length")
+	}
+
+	switch t {
+	case SignatureTypeSr25519:
+		if len(publicKey) != 32 {
+			return false, errors.New("wrong public key length")
+		}
+		var pk [32]byte
+		copy(pk[:], publicKey)
+		var sb [64]byte
+		copy(sb[:], sig)
+
+		s := new(schnorrkel.Signature)
+		err := s.Decode(sb)
+		if err != nil {
+			return false, err
+		}
+		return schnorrkel.NewPublicKey(pk).Verify(s, schnorrkel.NewSigningContext([]byte("substrate"), data)), nil
+	case SignatureTypeEd25519:
+		if len(publicKey) != stded25519.PublicKeySize {
+			return false, errors.New("wrong public key length")
+		}
+		return stded25519.Verify(publicKey, data, sig), nil
+	case SignatureTypeEcdsa:
+		digest := blake2b.Sum256(data)
+		return secp256k1.VerifySignature(publicKey, digest[:], sig[:64]), nil
+	default:
+		return false, fmt.Errorf("unsupported signature type %v", t)
+	}
+}
+
+// RecoverEcdsaPublicKey recovers the compressed public key from an ecdsa signature of data
+func RecoverEcdsaPublicKey(data []byte, sig []byte) ([]byte, error) {
+	// if data is longer than 256 bytes, hash it first
+	if len(data) > 256 {
+		h := blake2b.Sum256(data)
+		data = h[:]
+	}
+
+	if len(sig) != SignatureTypeEcdsa.signatureLength() {
+		return nil, errors.New("wrong signature length")
+	}
+
+	digest := blake2b.Sum256(data)
+	pub, err := secp256k1.SigToPub(digest[:], sig)
+	if err != nil {
+		return nil, err
+	}
+	return secp256k1.CompressPubkey(pub), nil
+}
+
 // LoadKeyringPairFromEnv looks up whether the env variable TEST_PRIV_KEY is set and is not empty and tries to use its
 // content as a private phrase, seed or URI to derive a key ring pair. Panics if the private phrase, seed or URI is
 // not valid or the keyring pair cannot be derived
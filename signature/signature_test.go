@@ -141,3 +141,93 @@ func TestSignAndVerifyLong(t *testing.T) {
 
 	assert.True(t, ok)
 }
+
+func TestKeyringPairFromSecretWithType_Ed25519(t *testing.T) {
+	p, err := KeyringPairFromSecretWithType("//Alice", 42, SignatureTypeEd25519)
+	assert.NoError(t, err)
+
+	assert.Equal(t, types.MustHexDecodeString("0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee"),
+		p.PublicKey)
+	assert.Equal(t, p.PublicKey, p.AccountID())
+	assert.Equal(t, SignatureTypeEd25519, p.SignatureType())
+}
+
+func TestKeyringPairFromSecretWithType_Ecdsa(t *testing.T) {
+	p, err := KeyringPairFromSecretWithType("//Alice", 42, SignatureTypeEcdsa)
+	assert.NoError(t, err)
+
+	assert.Equal(t, types.MustHexDecodeString("0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1"),
+		p.PublicKey)
+	assert.Equal(t, types.MustHexDecodeString("0x01e552298e47454041ea31273b4b630c64c104e4514aa3643490b8aaca9cf8ed"),
+		p.AccountID())
+	assert.Equal(t, SignatureTypeEcdsa, p.SignatureType())
+}
+
+func TestKeyringPairFromSecretWithType_InvalidType(t *testing.T) {
+	_, err := KeyringPairFromSecretWithType("//Alice", 42, SignatureType(3))
+	assert.EqualError(t, err, "unsupported signature type SignatureType(3)")
+}
+
+func TestSignAndVerifyWithType(t *testing.T) {
+	data := []byte("hello!")
+
+	for _, typ := range []SignatureType{SignatureTypeSr25519, SignatureTypeEd25519, SignatureTypeEcdsa} {
+		p, err := KeyringPairFromSecretWithType("//Alice", 42, typ)
+		assert.NoError(t, err)
+
+		sig, err := p.Sign(data)
+		assert.NoError(t, err)
+
+		ok, err := VerifyWithType(data, sig, p.URI, typ)
+		assert.NoError(t, err)
+		assert.True(t, ok, typ.String())
+
+		ok, err = VerifyWithType([]byte("hello?"), sig, p.URI, typ)
+		assert.NoError(t, err)
+		assert.False(t, ok, typ.String())
+	}
+}
+
+func TestVerifyWithType_InvalidSignatureLength(t *testing.T) {
+	sig, err := SignWithType([]byte("hello!"), TestKeyringPairAlice.URI, SignatureTypeSr25519)
+	assert.NoError(t, err)
+
+	_, err = VerifyWithType([]byte("hello!"), sig, TestKeyringPairAlice.URI, SignatureTypeEcdsa)
+	assert.Error(t, err)
+}
+
+func TestVerifyWithPublicKey(t *testing.T) {
+	data := []byte("hello!")
+
+	for _, typ := range []SignatureType{SignatureTypeSr25519, SignatureTypeEd25519, SignatureTypeEcdsa} {
+		p, err := KeyringPairFromSecretWithType("//Alice", 42, typ)
+		assert.NoError(t, err)
+
+		sig, err := p.Sign(data)
+		assert.NoError(t, err)
+
+		ok, err := VerifyWithPublicKey(data, sig, p.PublicKey, typ)
+		assert.NoError(t, err)
+		assert.True(t, ok, typ.String())
+
+		ok, err = VerifyWithPublicKey([]byte("hello?"), sig, p.PublicKey, typ)
+		assert.NoError(t, err)
+		assert.False(t, ok, typ.String())
+	}
+}
+
+func TestRecoverEcdsaPublicKey(t *testing.T) {
+	data := make([]byte, 258)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+
+	p, err := KeyringPairFromSecretWithType("//Alice", 42, SignatureTypeEcdsa)
+	assert.NoError(t, err)
+
+	sig, err := p.Sign(data)
+	assert.NoError(t, err)
+
+	pub, err := RecoverEcdsaPublicKey(data, sig)
+	assert.NoError(t, err)
+	assert.Equal(t, p.PublicKey, pub)
+}
@@ -0,0 +1,65 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/state"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// StorageDoubleMap is a handle for a storage map with two keys, e.g. Assets.Account
+type StorageDoubleMap struct {
+	entry *entry
+}
+
+// NewStorageDoubleMap creates a handle for the storage double map method of the pallet with the storage prefix,
+// usually the name of the pallet
+func NewStorageDoubleMap(s *state.State, meta *types.Metadata, prefix, method string) (*StorageDoubleMap, error) {
+	e, err := newEntry(s, meta, prefix, method, 2)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageDoubleMap{entry: e}, nil
+}
+
+// Key returns the storage key for the given map keys, which are SCALE encoded and hashed as declared in the metadata
+func (m *StorageDoubleMap) Key(key1, key2 interface{}) (types.StorageKey, error) {
+	return m.entry.key([]interface{}{key1, key2})
+}
+
+// Get reads the value of the given map keys at the given block and decodes it into target. If the keys are absent
+// and the map declares a default value, the default is decoded into target. Ok is false if neither exists.
+func (m *StorageDoubleMap) Get(key1, key2 interface{}, target interface{}, blockHash types.Hash) (ok bool,
+	err error) {
+	return m.entry.get([]interface{}{key1, key2}, target, &blockHash)
+}
+
+// GetLatest reads the value of the given map keys at the latest block height, see Get
+func (m *StorageDoubleMap) GetLatest(key1, key2 interface{}, target interface{}) (ok bool, err error) {
+	return m.entry.get([]interface{}{key1, key2}, target, nil)
+}
+
+// Iterate returns an iterator over all entries of the map at the given block
+func (m *StorageDoubleMap) Iterate(blockHash types.Hash) *Iterator {
+	return m.entry.iterate(nil, blockHash)
+}
+
+// IterateKey1 returns an iterator over all entries of the map with the given first key at the given block. The first
+// key must be hashed with a hasher that keeps keys with the same first key together, which all hashers do.
+func (m *StorageDoubleMap) IterateKey1(key1 interface{}, blockHash types.Hash) *Iterator {
+	return m.entry.iterate([]interface{}{key1}, blockHash)
+}
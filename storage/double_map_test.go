@@ -0,0 +1,72 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageDoubleMap(t *testing.T) {
+	prefs, err := NewStorageDoubleMap(testState, &meta, "Staking", "ErasValidatorPrefs")
+	assert.NoError(t, err)
+
+	// commission and blocked flag of ValidatorPrefs
+	type validatorPrefs struct {
+		Commission types.UCompact
+		Blocked    bool
+	}
+
+	for era, validators := range map[types.U32][]types.AccountID{10: {alice, bob}, 11: {alice}} {
+		for _, validator := range validators {
+			key, err := prefs.Key(era, validator)
+			assert.NoError(t, err)
+			mockSrv.put(key, validatorPrefs{Commission: types.NewUCompactFromUInt(uint64(era)), Blocked: false})
+		}
+	}
+
+	var p validatorPrefs
+	ok, err := prefs.GetLatest(types.U32(11), alice, &p)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.NewUCompactFromUInt(11), p.Commission)
+
+	it := prefs.IterateKey1(types.U32(10), types.Hash{})
+	n := 0
+	for it.Next() {
+		keys, err := it.Keys()
+		assert.NoError(t, err)
+		assert.Equal(t, types.U32(10), keys[0].Primitive)
+
+		err = it.Decode(&p)
+		assert.NoError(t, err)
+		assert.Equal(t, types.NewUCompactFromUInt(10), p.Commission)
+		n++
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 2, n)
+
+	it = prefs.Iterate(types.Hash{})
+	n = 0
+	for it.Next() {
+		n++
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, 3, n)
+}
@@ -0,0 +1,80 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/state"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// Iterator walks the entries of a storage map page by page, fetching their values in batches
+//
+//	it := accounts.Iterate(blockHash)
+//	for it.Next() {
+//		keys, err := it.Keys()
+//		...
+//		var info AccountInfo
+//		err = it.Decode(&info)
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator struct {
+	entry *entry
+	keys  *state.KeyIterator
+	err   error
+}
+
+// Next advances the iterator to the next entry. It returns false when all entries have been visited or an error
+// occurred, check Err to distinguish both cases.
+func (it *Iterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	return it.keys.Next()
+}
+
+// Key returns the storage key of the current entry
+func (it *Iterator) Key() types.StorageKey {
+	return it.keys.Key()
+}
+
+// Keys decodes the map keys of the current entry, which requires the map to use hashers that contain the original
+// keys, see types.DecodeStorageKey
+func (it *Iterator) Keys() ([]types.Value, error) {
+	return types.DecodeStorageKey(it.entry.meta, it.entry.prefix, it.entry.method, it.keys.Key())
+}
+
+// Raw returns the raw value of the current entry
+func (it *Iterator) Raw() *types.StorageDataRaw {
+	return it.keys.Value()
+}
+
+// Decode decodes the value of the current entry into target
+func (it *Iterator) Decode(target interface{}) error {
+	_, err := it.entry.meta.DecodeStorageWithDefault(it.keys.Key(), *it.keys.Value(), target)
+	return err
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.keys.Err()
+}
@@ -0,0 +1,58 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/state"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// StorageMap is a handle for a storage map with a single key, e.g. System.Account
+type StorageMap struct {
+	entry *entry
+}
+
+// NewStorageMap creates a handle for the storage map method of the pallet with the storage prefix, usually the name
+// of the pallet
+func NewStorageMap(s *state.State, meta *types.Metadata, prefix, method string) (*StorageMap, error) {
+	e, err := newEntry(s, meta, prefix, method, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageMap{entry: e}, nil
+}
+
+// Key returns the storage key for the given map key, which is SCALE encoded and hashed as declared in the metadata
+func (m *StorageMap) Key(key interface{}) (types.StorageKey, error) {
+	return m.entry.key([]interface{}{key})
+}
+
+// Get reads the value of the given map key at the given block and decodes it into target. If the key is absent and
+// the map declares a default value, the default is decoded into target. Ok is false if neither exists.
+func (m *StorageMap) Get(key interface{}, target interface{}, blockHash types.Hash) (ok bool, err error) {
+	return m.entry.get([]interface{}{key}, target, &blockHash)
+}
+
+// GetLatest reads the value of the given map key at the latest block height, see Get
+func (m *StorageMap) GetLatest(key interface{}, target interface{}) (ok bool, err error) {
+	return m.entry.get([]interface{}{key}, target, nil)
+}
+
+// Iterate returns an iterator over all entries of the map at the given block
+func (m *StorageMap) Iterate(blockHash types.Hash) *Iterator {
+	return m.entry.iterate(nil, blockHash)
+}
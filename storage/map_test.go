@@ -0,0 +1,110 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageMap_Get(t *testing.T) {
+	accounts, err := NewStorageMap(testState, &meta, "System", "Account")
+	assert.NoError(t, err)
+
+	key, err := accounts.Key(alice)
+	assert.NoError(t, err)
+	expected, err := types.CreateStorageKey(&meta, "System", "Account", alice[:])
+	assert.NoError(t, err)
+	assert.Equal(t, expected, key)
+
+	mockSrv.put(key, newAccountInfo(3, 1000))
+
+	var info accountInfo
+	ok, err := accounts.GetLatest(alice, &info)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, newAccountInfo(3, 1000), info)
+}
+
+func TestStorageMap_GetDefault(t *testing.T) {
+	accounts, err := NewStorageMap(testState, &meta, "System", "Account")
+	assert.NoError(t, err)
+
+	// System.Account is a ValueQuery, absent accounts have the zero AccountInfo
+	unknown := types.NewAccountID(make([]byte, 32))
+	info := newAccountInfo(3, 1000)
+	ok, err := accounts.Get(unknown, &info, types.Hash{})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, types.U32(0), info.Nonce)
+	assert.Equal(t, types.U32(0), info.Providers)
+	assert.Equal(t, "0", info.Data.Free.String())
+}
+
+func TestStorageMap_GetOptional(t *testing.T) {
+	bonded, err := NewStorageMap(testState, &meta, "Staking", "Bonded")
+	assert.NoError(t, err)
+
+	// Staking.Bonded is an OptionQuery without default
+	var controller types.AccountID
+	ok, err := bonded.GetLatest(types.NewAccountID(make([]byte, 32)), &controller)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestStorageMap_Iterate(t *testing.T) {
+	ledger, err := NewStorageMap(testState, &meta, "Staking", "Bonded")
+	assert.NoError(t, err)
+
+	for _, stash := range []types.AccountID{alice, bob} {
+		key, err := ledger.Key(stash)
+		assert.NoError(t, err)
+		mockSrv.put(key, stash)
+	}
+
+	it := ledger.Iterate(types.Hash{})
+	var stashes []types.AccountID
+	for it.Next() {
+		keys, err := it.Keys()
+		assert.NoError(t, err)
+		assert.Len(t, keys, 1)
+		bz, ok := keys[0].Fields[0].Value.Bytes()
+		assert.True(t, ok)
+
+		var controller types.AccountID
+		err = it.Decode(&controller)
+		assert.NoError(t, err)
+		assert.Equal(t, types.NewAccountID(bz), controller)
+
+		stashes = append(stashes, controller)
+	}
+	assert.NoError(t, it.Err())
+	assert.ElementsMatch(t, []types.AccountID{alice, bob}, stashes)
+}
+
+func TestNewStorageMap_Errors(t *testing.T) {
+	_, err := NewStorageMap(testState, &meta, "Timestamp", "Now")
+	assert.EqualError(t, err, "Timestamp.Now is not a storage map")
+
+	_, err = NewStorageMap(testState, &meta, "Staking", "ErasStakers")
+	assert.EqualError(t, err, "Staking.ErasStakers is a storage map with 2 keys, not 1")
+
+	_, err = NewStorageMap(testState, types.ExamplaryMetadataV10, "System", "Account")
+	assert.EqualError(t, err, "storage handles require metadata v14, got v10")
+}
@@ -0,0 +1,64 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/state"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// StorageNMap is a handle for a storage map with any number of keys
+type StorageNMap struct {
+	entry *entry
+}
+
+// NewStorageNMap creates a handle for the storage map method of the pallet with the storage prefix, usually the name
+// of the pallet
+func NewStorageNMap(s *state.State, meta *types.Metadata, prefix, method string) (*StorageNMap, error) {
+	e, err := newEntry(s, meta, prefix, method, -1)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageNMap{entry: e}, nil
+}
+
+// Len returns the number of keys of the map
+func (m *StorageNMap) Len() int {
+	return len(m.entry.entryMeta.Type.AsMap.Hasher)
+}
+
+// Key returns the storage key for the given map keys, which are SCALE encoded and hashed as declared in the metadata
+func (m *StorageNMap) Key(keys ...interface{}) (types.StorageKey, error) {
+	return m.entry.key(keys)
+}
+
+// Get reads the value of the given map keys at the given block and decodes it into target. If the keys are absent
+// and the map declares a default value, the default is decoded into target. Ok is false if neither exists.
+func (m *StorageNMap) Get(target interface{}, blockHash types.Hash, keys ...interface{}) (ok bool, err error) {
+	return m.entry.get(keys, target, &blockHash)
+}
+
+// GetLatest reads the value of the given map keys at the latest block height, see Get
+func (m *StorageNMap) GetLatest(target interface{}, keys ...interface{}) (ok bool, err error) {
+	return m.entry.get(keys, target, nil)
+}
+
+// Iterate returns an iterator over all entries of the map at the given block whose keys start with the given keys.
+// Pass no keys to iterate all entries.
+func (m *StorageNMap) Iterate(blockHash types.Hash, keys ...interface{}) *Iterator {
+	return m.entry.iterate(keys, blockHash)
+}
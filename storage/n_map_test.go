@@ -0,0 +1,41 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageNMap(t *testing.T) {
+	stakers, err := NewStorageNMap(testState, &meta, "Staking", "ErasStakersClipped")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stakers.Len())
+
+	key, err := stakers.Key(types.U32(12), bob)
+	assert.NoError(t, err)
+	expected, err := types.CreateStorageKey(&meta, "Staking", "ErasStakersClipped",
+		types.MustHexDecodeString("0x0c000000"), bob[:])
+	assert.NoError(t, err)
+	assert.Equal(t, expected, key)
+
+	it := stakers.Iterate(types.Hash{}, types.U32(12), bob, alice)
+	assert.False(t, it.Next())
+	assert.EqualError(t, it.Err(), "Staking.ErasStakersClipped has 2 keys, got 3")
+}
@@ -0,0 +1,153 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storage provides typed handles for the storage maps of a runtime. A handle is bound to a storage entry of a
+// pallet and encodes its keys with the hashers described in MetadataV14, so reading an entry is a single call:
+//
+//	accounts, err := storage.NewStorageMap(api.RPC.State, meta, "System", "Account")
+//	var info AccountInfo
+//	ok, err := accounts.GetLatest(types.NewAccountID(alice), &info)
+//
+// Like the runtime, handles return the default value from the metadata for absent keys of entries declared with a
+// default, and all entries of a map can be walked with an Iterator.
+package storage
+
+import (
+	"fmt"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/state"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+// entry is the storage entry a handle is bound to
+type entry struct {
+	state     *state.State
+	meta      *types.Metadata
+	prefix    string
+	method    string
+	entryMeta types.StorageEntryMetadataV14
+}
+
+// newEntry looks up the storage entry in the metadata, checking that it is a map with the given number of keys. A
+// negative number of keys allows any map.
+func newEntry(s *state.State, meta *types.Metadata, prefix, method string, keys int) (*entry, error) {
+	if !meta.IsMetadataV14 {
+		return nil, fmt.Errorf("storage handles require metadata v14, got v%v", meta.Version)
+	}
+
+	e, err := meta.AsMetadataV14.FindStorageEntryMetadata(prefix, method)
+	if err != nil {
+		return nil, err
+	}
+	entryMeta := e.(types.StorageEntryMetadataV14)
+
+	if !entryMeta.Type.IsMap {
+		return nil, fmt.Errorf("%v.%v is not a storage map", prefix, method)
+	}
+	if keys >= 0 && len(entryMeta.Type.AsMap.Hasher) != keys {
+		return nil, fmt.Errorf("%v.%v is a storage map with %v keys, not %v", prefix, method,
+			len(entryMeta.Type.AsMap.Hasher), keys)
+	}
+
+	return &entry{
+		state:     s,
+		meta:      meta,
+		prefix:    prefix,
+		method:    method,
+		entryMeta: entryMeta,
+	}, nil
+}
+
+// key SCALE encodes the args and creates the storage key from them
+func (e *entry) key(args []interface{}) (types.StorageKey, error) {
+	encoded, err := encodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	return types.CreateStorageKey(e.meta, e.prefix, e.method, encoded...)
+}
+
+// partialKey returns the prefix of all keys that start with the given args, which may be fewer than the keys of the map
+func (e *entry) partialKey(args []interface{}) (types.StorageKey, error) {
+	hashers := e.entryMeta.Type.AsMap.Hasher
+	if len(args) > len(hashers) {
+		return nil, fmt.Errorf("%v.%v has %v keys, got %v", e.prefix, e.method, len(hashers), len(args))
+	}
+
+	encoded, err := encodeArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	key := types.CreateStoragePrefix(e.prefix, e.method)
+	for i, arg := range encoded {
+		h, err := hashers[i].HashFunc()
+		if err != nil {
+			return nil, err
+		}
+		_, err = h.Write(arg)
+		if err != nil {
+			return nil, err
+		}
+		key = append(key, h.Sum(nil)...)
+	}
+	return key, nil
+}
+
+// get reads the value of the key built from args into target, at the given block or the latest one if blockHash is
+// nil
+func (e *entry) get(args []interface{}, target interface{}, blockHash *types.Hash) (ok bool, err error) {
+	key, err := e.key(args)
+	if err != nil {
+		return false, err
+	}
+
+	var raw *types.StorageDataRaw
+	if blockHash == nil {
+		raw, err = e.state.GetStorageRawLatest(key)
+	} else {
+		raw, err = e.state.GetStorageRaw(key, *blockHash)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return e.meta.DecodeStorageWithDefault(key, *raw, target)
+}
+
+// iterate returns an iterator over all keys starting with args
+func (e *entry) iterate(args []interface{}, blockHash types.Hash) *Iterator {
+	prefix, err := e.partialKey(args)
+	if err != nil {
+		return &Iterator{err: err}
+	}
+	return &Iterator{
+		entry: e,
+		keys:  e.state.NewKeyIterator(prefix, 0, blockHash).WithValues(),
+	}
+}
+
+func encodeArgs(args []interface{}) ([][]byte, error) {
+	encoded := make([][]byte, len(args))
+	for i, arg := range args {
+		var err error
+		encoded[i], err = types.EncodeToBytes(arg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode key %v: %v", i, err)
+		}
+	}
+	return encoded, nil
+}
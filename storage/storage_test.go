@@ -0,0 +1,120 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/client"
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/state"
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpcmocksrv"
+	"github.com/JFJun/go-substrate-rpc-client/v3/signature"
+	"github.com/JFJun/go-substrate-rpc-client/v3/types"
+)
+
+var (
+	testState *state.State
+	meta      types.Metadata
+	mockSrv   = mockState{storage: make(map[string]string)}
+)
+
+var (
+	alice = types.NewAccountID(signature.TestKeyringPairAlice.PublicKey)
+	bob   = types.NewAccountID(types.MustHexDecodeString(
+		"0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48"))
+)
+
+// accountInfo is the layout of System.Account in the polkadot metadata of types.MetadataV14Data
+type accountInfo struct {
+	Nonce       types.U32
+	Consumers   types.U32
+	Providers   types.U32
+	Sufficients types.U32
+	Data        struct {
+		Free       types.U128
+		Reserved   types.U128
+		MiscFrozen types.U128
+		FeeFrozen  types.U128
+	}
+}
+
+func newAccountInfo(nonce uint32, free int64) accountInfo {
+	var info accountInfo
+	info.Nonce = types.U32(nonce)
+	info.Providers = 1
+	info.Data.Free = types.NewU128(*big.NewInt(free))
+	info.Data.Reserved = types.NewU128(*big.NewInt(0))
+	info.Data.MiscFrozen = types.NewU128(*big.NewInt(0))
+	info.Data.FeeFrozen = types.NewU128(*big.NewInt(0))
+	return info
+}
+
+// mockState serves state_getStorage and state_getKeysPaged from a map of hex encoded keys to values
+type mockState struct {
+	storage map[string]string
+}
+
+func (s *mockState) GetStorage(key string, hash *string) string {
+	return s.storage[key]
+}
+
+func (s *mockState) GetKeysPaged(prefix string, count uint32, startKey *string, hash *string) []string {
+	keys := []string{}
+	for key := range s.storage {
+		if strings.HasPrefix(key, prefix) && (startKey == nil || key > *startKey) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if uint32(len(keys)) > count {
+		keys = keys[:count]
+	}
+	return keys
+}
+
+func (s *mockState) put(key types.StorageKey, value interface{}) {
+	enc, err := types.EncodeToHexString(value)
+	if err != nil {
+		panic(err)
+	}
+	s.storage[key.Hex()] = enc
+}
+
+func TestMain(m *testing.M) {
+	err := types.DecodeFromHexString(types.MetadataV14Data, &meta)
+	if err != nil {
+		panic(err)
+	}
+
+	s := rpcmocksrv.New()
+	err = s.RegisterName("state", &mockSrv)
+	if err != nil {
+		panic(err)
+	}
+
+	cl, err := client.Connect(s.URL)
+	if err != nil {
+		panic(err)
+	}
+	testState = state.NewState(cl)
+
+	os.Exit(m.Run())
+}
@@ -0,0 +1,55 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package teste2e
+
+import (
+	"testing"
+	"time"
+
+	gsrpc "github.com/JFJun/go-substrate-rpc-client/v3"
+	"github.com/JFJun/go-substrate-rpc-client/v3/config"
+	"github.com/JFJun/go-substrate-rpc-client/v3/rpc/grandpa"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrandpa_SubscribeJustifications(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping end-to-end test in short mode.")
+	}
+
+	api, err := gsrpc.NewSubstrateAPI(config.Default().RPCURL)
+	assert.NoError(t, err)
+
+	sub, err := grandpa.NewGrandpa(api.Client).SubscribeJustifications()
+	if err != nil && err.Error() == "Method not found" {
+		t.Skip("skipping since grandpa module is not available")
+	}
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	timeout := time.After(60 * time.Second)
+
+	select {
+	case justification := <-sub.Chan():
+		assert.NotEmpty(t, justification.Commit.Precommits)
+		for _, precommit := range justification.Commit.Precommits {
+			assert.GreaterOrEqual(t, precommit.Precommit.TargetNumber, justification.Commit.TargetNumber)
+		}
+	case <-timeout:
+		assert.FailNow(t, "timeout reached without getting a notification from subscription")
+	}
+}
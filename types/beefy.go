@@ -16,7 +16,11 @@
 
 package types
 
-import "github.com/JFJun/go-substrate-rpc-client/v3/scale"
+import (
+	"encoding/json"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/scale"
+)
 
 // Commitment is a beefy commitment
 type Commitment struct {
@@ -25,12 +29,63 @@ type Commitment struct {
 	ValidatorSetID U64
 }
 
+// Encode implements encoding for Commitment. Contrary to headers, the block number is encoded as a plain u32 and not
+// compact, which matters since validators sign the hash of the encoded commitment.
+func (c Commitment) Encode(encoder scale.Encoder) error {
+	err := encoder.Encode(c.Payload)
+	if err != nil {
+		return err
+	}
+	err = encoder.Encode(U32(c.BlockNumber))
+	if err != nil {
+		return err
+	}
+	return encoder.Encode(c.ValidatorSetID)
+}
+
+// Decode implements decoding for Commitment, see Encode
+func (c *Commitment) Decode(decoder scale.Decoder) error {
+	err := decoder.Decode(&c.Payload)
+	if err != nil {
+		return err
+	}
+	var number U32
+	err = decoder.Decode(&number)
+	if err != nil {
+		return err
+	}
+	c.BlockNumber = BlockNumber(number)
+	return decoder.Decode(&c.ValidatorSetID)
+}
+
 // SignedCommitment is a beefy commitment with optional signatures from the set of validators
 type SignedCommitment struct {
 	Commitment Commitment
 	Signatures []OptionBeefySignature
 }
 
+// UnmarshalJSON fills SignedCommitment with the JSON encoded byte array given by bz, which is the hex encoded
+// commitment as sent by beefy_subscribeJustifications
+func (s *SignedCommitment) UnmarshalJSON(bz []byte) error {
+	var tmp string
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+	return DecodeFromHexString(tmp, s)
+}
+
+// MarshalJSON returns a JSON encoded byte array of SignedCommitment
+func (s SignedCommitment) MarshalJSON() ([]byte, error) {
+	enc, err := EncodeToHexString(s)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(enc)
+}
+
+// BeefyAuthorityID is the compressed secp256k1 public key of a beefy validator, as stored in Beefy.Authorities
+type BeefyAuthorityID [33]byte
+
 // BeefySignature is a beefy signature
 type BeefySignature [65]byte
 
@@ -1,6 +1,7 @@
 package types_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/JFJun/go-substrate-rpc-client/v3/types"
@@ -21,3 +22,28 @@ func TestBeefySignature(t *testing.T) {
 	assert.True(t, ok)
 	assertRoundtrip(t, sig)
 }
+
+func TestCommitment_Encode(t *testing.T) {
+	c := types.Commitment{Payload: types.H256{1, 2, 3}, BlockNumber: 5, ValidatorSetID: 2}
+	assertRoundtrip(t, c)
+	// the block number is encoded as a plain u32
+	assertEncode(t, []encodingAssert{{c, types.MustHexDecodeString(
+		"0x0102030000000000000000000000000000000000000000000000000000000000050000000200000000000000")}})
+}
+
+func TestSignedCommitment_JSON(t *testing.T) {
+	sc := types.SignedCommitment{
+		Commitment: types.Commitment{Payload: types.H256{1, 2, 3}, BlockNumber: 5, ValidatorSetID: 2},
+		Signatures: []types.OptionBeefySignature{
+			types.NewOptionBeefySignature(types.BeefySignature{4, 5, 6}),
+			types.NewOptionBeefySignatureEmpty(),
+		},
+	}
+	bz, err := json.Marshal(sc)
+	assert.NoError(t, err)
+
+	var dec types.SignedCommitment
+	err = json.Unmarshal(bz, &dec)
+	assert.NoError(t, err)
+	assert.Equal(t, sc, dec)
+}
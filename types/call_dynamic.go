@@ -0,0 +1,112 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DecodedCall is a Call whose arguments have been decoded using the type information of MetadataV14
+type DecodedCall struct {
+	CallIndex CallIndex
+	Pallet    Text
+	Name      Text
+	Args      []DecodedCallArg
+}
+
+// DecodedCallArg is a single argument of a DecodedCall. If the argument contains calls, such as the call of
+// Sudo.sudo and Proxy.proxy or the calls of Utility.batch, they are decoded into Calls as well.
+type DecodedCallArg struct {
+	Name     Text
+	TypeName Text
+	Value    Value
+	Calls    []DecodedCall
+}
+
+// DecodeCall decodes the arguments of Call c into named fields using the given Metadata m, recursing into nested calls.
+// It requires metadata version 14 or later.
+func DecodeCall(m *Metadata, c Call) (DecodedCall, error) {
+	if !m.IsMetadataV14 {
+		return DecodedCall{}, errors.New("dynamic call decoding requires metadata v14")
+	}
+	meta := &m.AsMetadataV14
+
+	callType, err := meta.FindExtrinsicParamType("Call")
+	if err != nil {
+		return DecodedCall{}, err
+	}
+
+	bz, err := EncodeToBytes(c)
+	if err != nil {
+		return DecodedCall{}, err
+	}
+	v, err := DecodeWithType(meta, callType, bz)
+	if err != nil {
+		return DecodedCall{}, fmt.Errorf("unable to decode call %v: %v", c.CallIndex.String(), err)
+	}
+	return newDecodedCall(callType, v)
+}
+
+// newDecodedCall converts a value of the outer call enum, which wraps the call enum of each pallet, into a DecodedCall
+func newDecodedCall(callType int64, v Value) (DecodedCall, error) {
+	if v.Kind != ValueKindVariant || len(v.Fields) != 1 || v.Fields[0].Value.Kind != ValueKindVariant {
+		return DecodedCall{}, fmt.Errorf("type %v is not a call enum", v.TypeID)
+	}
+	call := v.Fields[0].Value
+
+	dc := DecodedCall{
+		CallIndex: CallIndex{SectionIndex: uint8(v.VariantIndex), MethodIndex: uint8(call.VariantIndex)},
+		Pallet:    v.VariantName,
+		Name:      call.VariantName,
+		Args:      make([]DecodedCallArg, 0, len(call.Fields)),
+	}
+	for _, f := range call.Fields {
+		arg := DecodedCallArg{Name: f.Name, TypeName: f.TypeName, Value: f.Value}
+		err := collectCalls(callType, f.Value, &arg.Calls)
+		if err != nil {
+			return DecodedCall{}, err
+		}
+		dc.Args = append(dc.Args, arg)
+	}
+	return dc, nil
+}
+
+// collectCalls appends all calls contained in v to calls, without descending into the calls themselves
+func collectCalls(callType int64, v Value, calls *[]DecodedCall) error {
+	if v.TypeID == callType {
+		dc, err := newDecodedCall(callType, v)
+		if err != nil {
+			return err
+		}
+		*calls = append(*calls, dc)
+		return nil
+	}
+	for _, f := range v.Fields {
+		err := collectCalls(callType, f.Value, calls)
+		if err != nil {
+			return err
+		}
+	}
+	for _, item := range v.Items {
+		err := collectCalls(callType, item, calls)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
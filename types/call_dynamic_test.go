@@ -0,0 +1,82 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCall(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	bob, err := NewMultiAddressFromHexAccountID("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+	assert.NoError(t, err)
+	transfer, err := NewCallFromArgs(&meta, "Balances.transfer", map[string]interface{}{
+		"dest":  bob,
+		"value": 12345,
+	})
+	assert.NoError(t, err)
+	proxy, err := NewCallFromArgs(&meta, "Proxy.proxy", map[string]interface{}{
+		"real":             bob.AsID,
+		"force_proxy_type": map[string]interface{}{"None": nil},
+		"call":             transfer,
+	})
+	assert.NoError(t, err)
+	batch, err := NewCallFromArgs(&meta, "Utility.batch", map[string]interface{}{
+		"calls": []Call{transfer, proxy},
+	})
+	assert.NoError(t, err)
+
+	decoded, err := DecodeCall(&meta, batch)
+	assert.NoError(t, err)
+	assert.Equal(t, batch.CallIndex, decoded.CallIndex)
+	assert.Equal(t, Text("Utility"), decoded.Pallet)
+	assert.Equal(t, Text("batch"), decoded.Name)
+	assert.Len(t, decoded.Args, 1)
+	assert.Equal(t, Text("calls"), decoded.Args[0].Name)
+	assert.Len(t, decoded.Args[0].Calls, 2)
+
+	decodedTransfer := decoded.Args[0].Calls[0]
+	assert.Equal(t, Text("Balances"), decodedTransfer.Pallet)
+	assert.Equal(t, Text("transfer"), decodedTransfer.Name)
+	assert.Equal(t, Text("dest"), decodedTransfer.Args[0].Name)
+	assert.Equal(t, Text("Id"), decodedTransfer.Args[0].Value.VariantName)
+	assert.Equal(t, NewUCompactFromUInt(12345), decodedTransfer.Args[1].Value.Primitive)
+	assert.Empty(t, decodedTransfer.Args[1].Calls)
+
+	decodedProxy := decoded.Args[0].Calls[1]
+	assert.Equal(t, Text("Proxy"), decodedProxy.Pallet)
+	assert.Equal(t, Text("proxy"), decodedProxy.Name)
+	assert.Equal(t, Text("None"), decodedProxy.Args[1].Value.VariantName)
+	assert.Equal(t, []DecodedCall{decodedTransfer}, decodedProxy.Args[2].Calls)
+}
+
+func TestDecodeCall_UnknownCall(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	_, err = DecodeCall(&meta, Call{CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 99}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to decode call 0563")
+	assert.Contains(t, err.Error(), "variant index 99 not found")
+}
@@ -17,13 +17,16 @@
 package types
 
 import (
+	"encoding/json"
+
 	"github.com/JFJun/go-substrate-rpc-client/v3/scale"
 )
 
-// ChainProperties contains the SS58 format, the token decimals and the token symbol
+// ChainProperties contains the SS58 format, the token decimals and the token symbol. The SS58 format is a U16 to hold
+// the two-byte prefixes 64 to 16383.
 type ChainProperties struct {
 	IsSS58Format    bool
-	AsSS58Format    U8
+	AsSS58Format    U16
 	IsTokenDecimals bool
 	AsTokenDecimals U32
 	IsTokenSymbol   bool
@@ -57,3 +60,76 @@ func (a ChainProperties) Encode(encoder scale.Encoder) error {
 
 	return nil
 }
+
+// chainPropertiesJSON is the JSON object returned by system_properties
+type chainPropertiesJSON struct {
+	SS58Format    *U16            `json:"ss58Format,omitempty"`
+	TokenDecimals json.RawMessage `json:"tokenDecimals,omitempty"`
+	TokenSymbol   json.RawMessage `json:"tokenSymbol,omitempty"`
+}
+
+// UnmarshalJSON fills ChainProperties with the JSON encoded byte array given by bz, as returned by system_properties.
+// Chains with several tokens list their decimals and symbols, of which the first ones are used.
+func (a *ChainProperties) UnmarshalJSON(bz []byte) error {
+	var tmp chainPropertiesJSON
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+
+	*a = ChainProperties{}
+	if tmp.SS58Format != nil {
+		a.IsSS58Format = true
+		a.AsSS58Format = *tmp.SS58Format
+	}
+
+	var err error
+	a.IsTokenDecimals, err = unmarshalFirst(tmp.TokenDecimals, &a.AsTokenDecimals)
+	if err != nil {
+		return err
+	}
+	a.IsTokenSymbol, err = unmarshalFirst(tmp.TokenSymbol, &a.AsTokenSymbol)
+	return err
+}
+
+// MarshalJSON returns a JSON encoded byte array of ChainProperties in the format of system_properties
+func (a ChainProperties) MarshalJSON() ([]byte, error) {
+	var tmp chainPropertiesJSON
+	if a.IsSS58Format {
+		tmp.SS58Format = &a.AsSS58Format
+	}
+	if a.IsTokenDecimals {
+		bz, err := json.Marshal(a.AsTokenDecimals)
+		if err != nil {
+			return nil, err
+		}
+		tmp.TokenDecimals = bz
+	}
+	if a.IsTokenSymbol {
+		bz, err := json.Marshal(a.AsTokenSymbol)
+		if err != nil {
+			return nil, err
+		}
+		tmp.TokenSymbol = bz
+	}
+	return json.Marshal(tmp)
+}
+
+// unmarshalFirst decodes bz, either a single value or a list of values, into target, taking the first element of a
+// list. It returns false if bz is absent, null or an empty list.
+func unmarshalFirst(bz json.RawMessage, target interface{}) (bool, error) {
+	if len(bz) == 0 || string(bz) == "null" {
+		return false, nil
+	}
+	if bz[0] != '[' {
+		return true, json.Unmarshal(bz, target)
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(bz, &list); err != nil {
+		return false, err
+	}
+	if len(list) == 0 {
+		return false, nil
+	}
+	return true, json.Unmarshal(list[0], target)
+}
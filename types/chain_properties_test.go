@@ -17,9 +17,11 @@
 package types_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	. "github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
 )
 
 var testChainProperties1 = ChainProperties{}
@@ -37,13 +39,45 @@ func TestChainProperties_EncodeDecode(t *testing.T) {
 func TestChainProperties_Encode(t *testing.T) {
 	assertEncode(t, []encodingAssert{
 		{testChainProperties1, []byte{0x0, 0x0, 0x0}},
-		{testChainProperties2, []byte{0x01, 0x01, 0x01, 0x12, 0x00, 0x00, 0x00, 0x01, 0x0c, 0x46, 0x4f, 0x4f}},
+		{testChainProperties2, []byte{0x01, 0x01, 0x00, 0x01, 0x12, 0x00, 0x00, 0x00, 0x01, 0x0c, 0x46, 0x4f, 0x4f}},
 	})
 }
 
 func TestChainProperties_Decode(t *testing.T) {
 	assertDecode(t, []decodingAssert{
 		{[]byte{0x0, 0x0, 0x0}, testChainProperties1},
-		{[]byte{0x01, 0x01, 0x01, 0x12, 0x00, 0x00, 0x00, 0x01, 0x0c, 0x46, 0x4f, 0x4f}, testChainProperties2},
+		{[]byte{0x01, 0x01, 0x00, 0x01, 0x12, 0x00, 0x00, 0x00, 0x01, 0x0c, 0x46, 0x4f, 0x4f}, testChainProperties2},
 	})
 }
+
+func TestChainProperties_UnmarshalJSON(t *testing.T) {
+	var p ChainProperties
+	err := json.Unmarshal([]byte(`{"ss58Format":2,"tokenDecimals":12,"tokenSymbol":"KSM"}`), &p)
+	assert.NoError(t, err)
+	assert.Equal(t, ChainProperties{IsSS58Format: true, AsSS58Format: 2, IsTokenDecimals: true, AsTokenDecimals: 12,
+		IsTokenSymbol: true, AsTokenSymbol: "KSM"}, p)
+	assert.Equal(t, uint16(2), p.SS58Prefix())
+
+	// two-byte prefixes and chains with several tokens
+	err = json.Unmarshal([]byte(`{"ss58Format":1284,"tokenDecimals":[18,12],"tokenSymbol":["GLMR","DOT"]}`), &p)
+	assert.NoError(t, err)
+	assert.Equal(t, ChainProperties{IsSS58Format: true, AsSS58Format: 1284, IsTokenDecimals: true,
+		AsTokenDecimals: 18, IsTokenSymbol: true, AsTokenSymbol: "GLMR"}, p)
+	assert.Equal(t, uint16(1284), p.SS58Prefix())
+
+	err = json.Unmarshal([]byte(`{}`), &p)
+	assert.NoError(t, err)
+	assert.Equal(t, ChainProperties{}, p)
+}
+
+func TestChainProperties_JSONRoundtrip(t *testing.T) {
+	p := ChainProperties{IsSS58Format: true, AsSS58Format: 1284, IsTokenSymbol: true, AsTokenSymbol: "GLMR"}
+	bz, err := json.Marshal(p)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"ss58Format":1284,"tokenSymbol":"GLMR"}`, string(bz))
+
+	var dec ChainProperties
+	err = json.Unmarshal(bz, &dec)
+	assert.NoError(t, err)
+	assert.Equal(t, p, dec)
+}
@@ -0,0 +1,511 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/scale"
+)
+
+// DispatchError is an error occurring during extrinsic dispatch. It is decoded with the variant indices of current
+// runtimes, older runtimes without some of the variants use different indices. For those, decode the error with
+// DecodeDynamicEventRecords and convert it with NewDispatchErrorFromValue, which identifies variants by their names.
+//
+// Older runtimes encode the error of a module with a single byte, newer ones with four bytes, the first of which is
+// the index of the error in the pallet while the others carry details of nested errors. Decode assumes a single byte,
+// Metadata.DecodeDispatchError and EventRecordsRaw.DecodeEventRecords use the length declared by the metadata.
+//
+// https://github.com/paritytech/substrate/blob/master/primitives/runtime/src/lib.rs
+type DispatchError struct {
+	// Some error occurred, the reason is not encoded
+	IsOther bool
+	// Failed to lookup some data
+	IsCannotLookup bool
+	// A bad origin
+	IsBadOrigin bool
+	// A custom error in a module, the index of the module in the runtime and the index of the error in the module
+	HasModule bool
+	Module    uint8
+	Error     uint8
+	// HasErrorDetails is true if the error of the module is encoded with four bytes, the last three being ErrorDetails
+	HasErrorDetails bool
+	ErrorDetails    [3]uint8
+	// At least one consumer is remaining so the account cannot be destroyed
+	IsConsumerRemaining bool
+	// There are no providers so the account cannot be created
+	IsNoProviders bool
+	// There are too many consumers so the account cannot be created
+	IsTooManyConsumers bool
+	// An error to do with tokens
+	IsToken bool
+	AsToken TokenError
+	// An arithmetic error
+	IsArithmetic bool
+	AsArithmetic ArithmeticError
+	// The number of transactional layers has been reached, or we are not in a transactional layer
+	IsTransactional bool
+	AsTransactional TransactionalError
+	// Resources exhausted, e.g. attempt to read/write data which is too large to manipulate
+	IsExhausted bool
+	// The state is corrupt, this is generally not going to fix itself
+	IsCorruption bool
+	// Some resource (e.g. a preimage) is unavailable right now, this might fix itself later
+	IsUnavailable bool
+	// The root origin is not allowed
+	IsRootNotAllowed bool
+}
+
+// Decode implements decoding for DispatchError, assuming module errors are encoded with a single byte
+func (d *DispatchError) Decode(decoder scale.Decoder) error {
+	return d.decodeWithModuleErrorLength(decoder, 1)
+}
+
+// decodeWithModuleErrorLength decodes d with module errors being encoded with the given number of bytes, 1 or 4
+func (d *DispatchError) decodeWithModuleErrorLength(decoder scale.Decoder, moduleErrorLength int) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		d.IsOther = true
+	case 1:
+		d.IsCannotLookup = true
+	case 2:
+		d.IsBadOrigin = true
+	case 3:
+		d.HasModule = true
+		return d.decodeModuleError(decoder, moduleErrorLength)
+	case 4:
+		d.IsConsumerRemaining = true
+	case 5:
+		d.IsNoProviders = true
+	case 6:
+		d.IsTooManyConsumers = true
+	case 7:
+		d.IsToken = true
+		return decoder.Decode(&d.AsToken)
+	case 8:
+		d.IsArithmetic = true
+		return decoder.Decode(&d.AsArithmetic)
+	case 9:
+		d.IsTransactional = true
+		return decoder.Decode(&d.AsTransactional)
+	case 10:
+		d.IsExhausted = true
+	case 11:
+		d.IsCorruption = true
+	case 12:
+		d.IsUnavailable = true
+	case 13:
+		d.IsRootNotAllowed = true
+	default:
+		return fmt.Errorf("unknown DispatchError enum: %v", b)
+	}
+	return nil
+}
+
+func (d *DispatchError) decodeModuleError(decoder scale.Decoder, moduleErrorLength int) error {
+	err := decoder.Decode(&d.Module)
+	if err != nil {
+		return err
+	}
+	err = decoder.Decode(&d.Error)
+	if err != nil {
+		return err
+	}
+
+	switch moduleErrorLength {
+	case 1:
+		return nil
+	case 4:
+		d.HasErrorDetails = true
+		return decoder.Decode(&d.ErrorDetails)
+	default:
+		return fmt.Errorf("unsupported module error length %v", moduleErrorLength)
+	}
+}
+
+func (d DispatchError) Encode(encoder scale.Encoder) error {
+	switch {
+	case d.IsCannotLookup:
+		return encoder.PushByte(1)
+	case d.IsBadOrigin:
+		return encoder.PushByte(2)
+	case d.HasModule:
+		return d.encodeModuleError(encoder)
+	case d.IsConsumerRemaining:
+		return encoder.PushByte(4)
+	case d.IsNoProviders:
+		return encoder.PushByte(5)
+	case d.IsTooManyConsumers:
+		return encoder.PushByte(6)
+	case d.IsToken:
+		err := encoder.PushByte(7)
+		if err != nil {
+			return err
+		}
+		return encoder.Encode(d.AsToken)
+	case d.IsArithmetic:
+		err := encoder.PushByte(8)
+		if err != nil {
+			return err
+		}
+		return encoder.Encode(d.AsArithmetic)
+	case d.IsTransactional:
+		err := encoder.PushByte(9)
+		if err != nil {
+			return err
+		}
+		return encoder.Encode(d.AsTransactional)
+	case d.IsExhausted:
+		return encoder.PushByte(10)
+	case d.IsCorruption:
+		return encoder.PushByte(11)
+	case d.IsUnavailable:
+		return encoder.PushByte(12)
+	case d.IsRootNotAllowed:
+		return encoder.PushByte(13)
+	default:
+		return encoder.PushByte(0)
+	}
+}
+
+func (d DispatchError) encodeModuleError(encoder scale.Encoder) error {
+	err := encoder.PushByte(3)
+	if err != nil {
+		return err
+	}
+	err = encoder.Encode(d.Module)
+	if err != nil {
+		return err
+	}
+	err = encoder.Encode(d.Error)
+	if err != nil {
+		return err
+	}
+
+	if d.HasErrorDetails {
+		return encoder.Encode(d.ErrorDetails)
+	}
+	return nil
+}
+
+// String returns the name of the variant of d. Module errors are printed by their indices, use Metadata.FindError to
+// look up their names.
+func (d DispatchError) String() string {
+	switch {
+	case d.IsCannotLookup:
+		return "CannotLookup"
+	case d.IsBadOrigin:
+		return "BadOrigin"
+	case d.HasModule:
+		return fmt.Sprintf("Module{Index: %v, Error: %v}", d.Module, d.Error)
+	case d.IsConsumerRemaining:
+		return "ConsumerRemaining"
+	case d.IsNoProviders:
+		return "NoProviders"
+	case d.IsTooManyConsumers:
+		return "TooManyConsumers"
+	case d.IsToken:
+		return "Token." + d.AsToken.String()
+	case d.IsArithmetic:
+		return "Arithmetic." + d.AsArithmetic.String()
+	case d.IsTransactional:
+		return "Transactional." + d.AsTransactional.String()
+	case d.IsExhausted:
+		return "Exhausted"
+	case d.IsCorruption:
+		return "Corruption"
+	case d.IsUnavailable:
+		return "Unavailable"
+	case d.IsRootNotAllowed:
+		return "RootNotAllowed"
+	default:
+		return "Other"
+	}
+}
+
+// TokenError is the reason a token operation failed
+type TokenError struct {
+	// Funds are unavailable
+	IsNoFunds bool
+	// Account that must exist would die
+	IsWouldDie bool
+	// Account cannot exist with the funds that would be given
+	IsBelowMinimum bool
+	// Account cannot be created
+	IsCannotCreate bool
+	// The asset in question is unknown
+	IsUnknownAsset bool
+	// Funds exist but are frozen
+	IsFrozen bool
+	// Operation is not supported by the asset
+	IsUnsupported bool
+}
+
+var tokenErrorNames = []string{
+	"NoFunds", "WouldDie", "BelowMinimum", "CannotCreate", "UnknownAsset", "Frozen", "Unsupported",
+}
+
+func (t *TokenError) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		t.IsNoFunds = true
+	case 1:
+		t.IsWouldDie = true
+	case 2:
+		t.IsBelowMinimum = true
+	case 3:
+		t.IsCannotCreate = true
+	case 4:
+		t.IsUnknownAsset = true
+	case 5:
+		t.IsFrozen = true
+	case 6:
+		t.IsUnsupported = true
+	default:
+		return fmt.Errorf("unknown TokenError enum: %v", b)
+	}
+	return nil
+}
+
+func (t TokenError) Encode(encoder scale.Encoder) error {
+	return encoder.PushByte(t.index())
+}
+
+func (t TokenError) String() string {
+	return tokenErrorNames[t.index()]
+}
+
+func (t TokenError) index() byte {
+	switch {
+	case t.IsWouldDie:
+		return 1
+	case t.IsBelowMinimum:
+		return 2
+	case t.IsCannotCreate:
+		return 3
+	case t.IsUnknownAsset:
+		return 4
+	case t.IsFrozen:
+		return 5
+	case t.IsUnsupported:
+		return 6
+	default:
+		return 0
+	}
+}
+
+// ArithmeticError is the reason an arithmetic operation failed
+type ArithmeticError struct {
+	// Underflow
+	IsUnderflow bool
+	// Overflow
+	IsOverflow bool
+	// Division by zero
+	IsDivisionByZero bool
+}
+
+var arithmeticErrorNames = []string{"Underflow", "Overflow", "DivisionByZero"}
+
+func (a *ArithmeticError) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		a.IsUnderflow = true
+	case 1:
+		a.IsOverflow = true
+	case 2:
+		a.IsDivisionByZero = true
+	default:
+		return fmt.Errorf("unknown ArithmeticError enum: %v", b)
+	}
+	return nil
+}
+
+func (a ArithmeticError) Encode(encoder scale.Encoder) error {
+	return encoder.PushByte(a.index())
+}
+
+func (a ArithmeticError) String() string {
+	return arithmeticErrorNames[a.index()]
+}
+
+func (a ArithmeticError) index() byte {
+	switch {
+	case a.IsOverflow:
+		return 1
+	case a.IsDivisionByZero:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// TransactionalError is the reason a storage transaction failed
+type TransactionalError struct {
+	// Too many transactional layers have been spawned
+	IsLimitReached bool
+	// A transactional layer was expected, but does not exist
+	IsNoLayer bool
+}
+
+var transactionalErrorNames = []string{"LimitReached", "NoLayer"}
+
+func (t *TransactionalError) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 0:
+		t.IsLimitReached = true
+	case 1:
+		t.IsNoLayer = true
+	default:
+		return fmt.Errorf("unknown TransactionalError enum: %v", b)
+	}
+	return nil
+}
+
+func (t TransactionalError) Encode(encoder scale.Encoder) error {
+	return encoder.PushByte(t.index())
+}
+
+func (t TransactionalError) String() string {
+	return transactionalErrorNames[t.index()]
+}
+
+func (t TransactionalError) index() byte {
+	if t.IsNoLayer {
+		return 1
+	}
+	return 0
+}
+
+// NewDispatchErrorFromValue converts a DispatchError decoded with the V14 type registry, e.g. a field of a
+// DynamicEventRecord, to a DispatchError. Variants are matched by name, so it works regardless of the variant indices
+// and the module error length of the runtime.
+func NewDispatchErrorFromValue(v Value) (DispatchError, error) {
+	var d DispatchError
+	if v.Kind != ValueKindVariant {
+		return d, fmt.Errorf("expected a variant for DispatchError, got a %v", v.Kind)
+	}
+
+	var err error
+	switch v.VariantName {
+	case "Other":
+		d.IsOther = true
+	case "CannotLookup":
+		d.IsCannotLookup = true
+	case "BadOrigin":
+		d.IsBadOrigin = true
+	case "Module":
+		d.HasModule = true
+		err = d.setModuleErrorFromValue(v)
+	case "ConsumerRemaining":
+		d.IsConsumerRemaining = true
+	case "NoProviders":
+		d.IsNoProviders = true
+	case "TooManyConsumers":
+		d.IsTooManyConsumers = true
+	case "Token":
+		d.IsToken = true
+		err = decodeVariantByName(v, tokenErrorNames, &d.AsToken)
+	case "Arithmetic":
+		d.IsArithmetic = true
+		err = decodeVariantByName(v, arithmeticErrorNames, &d.AsArithmetic)
+	case "Transactional":
+		d.IsTransactional = true
+		err = decodeVariantByName(v, transactionalErrorNames, &d.AsTransactional)
+	case "Exhausted":
+		d.IsExhausted = true
+	case "Corruption":
+		d.IsCorruption = true
+	case "Unavailable":
+		d.IsUnavailable = true
+	case "RootNotAllowed":
+		d.IsRootNotAllowed = true
+	default:
+		return d, fmt.Errorf("unknown DispatchError variant %v", v.VariantName)
+	}
+	return d, err
+}
+
+// setModuleErrorFromValue sets the module and error of d from a Module variant, which either holds the fields index
+// and error directly or, in newer runtimes, a ModuleError struct with a 4 byte error
+func (d *DispatchError) setModuleErrorFromValue(v Value) error {
+	fields := v
+	if _, ok := v.Field("index"); !ok && len(v.Fields) == 1 {
+		fields = v.Fields[0].Value
+	}
+
+	index, ok := fields.Field("index")
+	if !ok {
+		return fmt.Errorf("module error has no index")
+	}
+	module, ok := index.Primitive.(U8)
+	if !ok {
+		return fmt.Errorf("expected a U8 module index, got %T", index.Primitive)
+	}
+	d.Module = uint8(module)
+
+	e, ok := fields.Field("error")
+	if !ok {
+		return fmt.Errorf("module error has no error")
+	}
+	if u, ok := e.Primitive.(U8); ok {
+		d.Error = uint8(u)
+		return nil
+	}
+	bz, ok := e.Bytes()
+	if !ok || len(bz) != 4 {
+		return fmt.Errorf("expected a U8 or 4 bytes as module error, got %v", e.Kind)
+	}
+	d.Error = bz[0]
+	d.HasErrorDetails = true
+	copy(d.ErrorDetails[:], bz[1:])
+	return nil
+}
+
+// decodeVariantByName decodes the fieldless enum held by the single field of v into target, identifying its variant by
+// the position of its name in names
+func decodeVariantByName(v Value, names []string, target interface{}) error {
+	if len(v.Fields) != 1 {
+		return fmt.Errorf("expected one field in %v, got %v", v.VariantName, len(v.Fields))
+	}
+	name := string(v.Fields[0].Value.VariantName)
+	for i, n := range names {
+		if n == name {
+			return DecodeFromBytes([]byte{byte(i)}, target)
+		}
+	}
+	return fmt.Errorf("unknown %v error %v", v.VariantName, name)
+}
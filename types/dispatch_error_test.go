@@ -0,0 +1,197 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatchError_EncodeDecode(t *testing.T) {
+	assertRoundtrip(t, DispatchError{IsCannotLookup: true})
+	assertRoundtrip(t, DispatchError{IsBadOrigin: true})
+	assertRoundtrip(t, DispatchError{IsTooManyConsumers: true})
+	assertRoundtrip(t, DispatchError{IsToken: true, AsToken: TokenError{IsFrozen: true}})
+	assertRoundtrip(t, DispatchError{IsArithmetic: true, AsArithmetic: ArithmeticError{IsDivisionByZero: true}})
+	assertRoundtrip(t, DispatchError{IsTransactional: true, AsTransactional: TransactionalError{IsNoLayer: true}})
+	assertRoundtrip(t, DispatchError{IsRootNotAllowed: true})
+
+	var d DispatchError
+	err := DecodeFromBytes([]byte{7, 1}, &d)
+	assert.NoError(t, err)
+	assert.Equal(t, DispatchError{IsToken: true, AsToken: TokenError{IsWouldDie: true}}, d)
+	assert.Equal(t, "Token.WouldDie", d.String())
+
+	err = DecodeFromBytes([]byte{14}, &d)
+	assert.EqualError(t, err, "unknown DispatchError enum: 14")
+}
+
+func TestDispatchError_ErrorDetails(t *testing.T) {
+	d := DispatchError{HasModule: true, Module: 5, Error: 2, HasErrorDetails: true, ErrorDetails: [3]uint8{1, 0, 0}}
+	assertEncode(t, []encodingAssert{{d, MustHexDecodeString("0x030502010000")}})
+	assert.Equal(t, "Module{Index: 5, Error: 2}", d.String())
+
+	// without metadata, module errors are decoded with the one byte layout of older runtimes
+	var dec DispatchError
+	err := DecodeFromBytes(MustHexDecodeString("0x030502"), &dec)
+	assert.NoError(t, err)
+	assert.Equal(t, DispatchError{HasModule: true, Module: 5, Error: 2}, dec)
+}
+
+func TestMetadata_DecodeDispatchError(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	d, err := meta.DecodeDispatchError(MustHexDecodeString("0x030502"))
+	assert.NoError(t, err)
+	assert.Equal(t, DispatchError{HasModule: true, Module: 5, Error: 2}, d)
+
+	d, err = ExamplaryMetadataV10.DecodeDispatchError(MustHexDecodeString("0x030502"))
+	assert.NoError(t, err)
+	assert.Equal(t, DispatchError{HasModule: true, Module: 5, Error: 2}, d)
+
+	// the length is cached, so the metadata is changed before the first decoding
+	meta = Metadata{}
+	err = DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+	setFourByteModuleErrors(t, &meta)
+	n, err := meta.FindModuleErrorLength()
+	assert.NoError(t, err)
+	assert.Equal(t, 4, n)
+
+	d, err = meta.DecodeDispatchError(MustHexDecodeString("0x030502010000"))
+	assert.NoError(t, err)
+	exp := DispatchError{HasModule: true, Module: 5, Error: 2, HasErrorDetails: true, ErrorDetails: [3]uint8{1, 0, 0}}
+	assert.Equal(t, exp, d)
+	enc, err := EncodeToBytes(d)
+	assert.NoError(t, err)
+	assert.Equal(t, MustHexDecodeString("0x030502010000"), enc)
+
+	_, err = meta.DecodeDispatchError(MustHexDecodeString("0x030502"))
+	assert.Error(t, err)
+
+	// runtimes not declaring a DispatchError fall back to one byte module errors
+	meta = Metadata{}
+	err = DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+	id := dispatchErrorTypeID(t, &meta)
+	for i := range meta.AsMetadataV14.Lookup {
+		if meta.AsMetadataV14.Lookup[i].Id.Int64() == id {
+			meta.AsMetadataV14.Lookup[i].Type.Path = []Text{"sp_runtime", "OtherError"}
+		}
+	}
+	_, err = meta.FindModuleErrorLength()
+	assert.EqualError(t, err, "DispatchError not found in metadata")
+	d, err = meta.DecodeDispatchError(MustHexDecodeString("0x030502"))
+	assert.NoError(t, err)
+	assert.Equal(t, DispatchError{HasModule: true, Module: 5, Error: 2}, d)
+}
+
+func TestNewDispatchErrorFromValue(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	// in this runtime, Token is the variant with index 6, which is TooManyConsumers in newer runtimes
+	v, err := DecodeWithType(&meta.AsMetadataV14, dispatchErrorTypeID(t, &meta), []byte{6, 2})
+	assert.NoError(t, err)
+	d, err := NewDispatchErrorFromValue(v)
+	assert.NoError(t, err)
+	assert.Equal(t, DispatchError{IsToken: true, AsToken: TokenError{IsBelowMinimum: true}}, d)
+
+	v, err = DecodeWithType(&meta.AsMetadataV14, dispatchErrorTypeID(t, &meta), []byte{3, 5, 2})
+	assert.NoError(t, err)
+	d, err = NewDispatchErrorFromValue(v)
+	assert.NoError(t, err)
+	assert.Equal(t, DispatchError{HasModule: true, Module: 5, Error: 2}, d)
+
+	_, err = NewDispatchErrorFromValue(Value{Kind: ValueKindVariant, VariantName: "Unknown"})
+	assert.EqualError(t, err, "unknown DispatchError variant Unknown")
+}
+
+func TestMetadata_FindError(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	e, err := meta.FindError(5, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "Balances.InsufficientBalance", e.String())
+	assert.Equal(t, []Text{"Balance too low to send value"}, e.Docs)
+
+	_, err = meta.FindError(5, 200)
+	assert.EqualError(t, err, "error of module Balances: variant index 200 not found")
+
+	_, err = meta.FindError(255, 0)
+	assert.EqualError(t, err, "module index 255 out of range")
+
+	n, err := meta.FindModuleErrorLength()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	_, err = ExamplaryMetadataV10.FindError(5, 2)
+	assert.EqualError(t, err, "finding errors requires metadata v14, got v10")
+}
+
+func dispatchErrorTypeID(t *testing.T, meta *Metadata) int64 {
+	for _, lookUp := range meta.AsMetadataV14.Lookup {
+		path := lookUp.Type.Path
+		if len(path) == 2 && path[0] == "sp_runtime" && path[1] == "DispatchError" {
+			return lookUp.Id.Int64()
+		}
+	}
+	t.Fatal("DispatchError not found in metadata")
+	return 0
+}
+
+// setFourByteModuleErrors points the error field of the module errors in the metadata to a four byte array, as
+// declared by newer runtimes
+func setFourByteModuleErrors(t *testing.T, meta *Metadata) {
+	var arrayID Si1LookupTypeId
+	found := false
+	for _, lookUp := range meta.AsMetadataV14.Lookup {
+		if lookUp.Type.Def.IsArray && lookUp.Type.Def.Array.Len == 4 {
+			arrayID, found = lookUp.Id, true
+			break
+		}
+	}
+	if !found {
+		t.Fatal("four byte array not found in metadata")
+	}
+
+	id := dispatchErrorTypeID(t, meta)
+	for _, lookUp := range meta.AsMetadataV14.Lookup {
+		if lookUp.Id.Int64() != id {
+			continue
+		}
+		for _, v := range lookUp.Type.Def.Variant.Variants {
+			if v.Name != "Module" {
+				continue
+			}
+			for i := range v.Fields {
+				if v.Fields[i].Name == "error" {
+					v.Fields[i].Type = arrayID
+					return
+				}
+			}
+		}
+	}
+	t.Fatal("module error not found in metadata")
+}
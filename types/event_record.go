@@ -242,6 +242,9 @@ func (e EventRecordsRaw) DecodeEventRecords(m *Metadata, t interface{}) error {
 		return fmt.Errorf("target must point to a struct, but is " + fmt.Sprint(typ))
 	}
 
+	// the layout of module errors in DispatchErrors depends on the runtime
+	moduleErrorLength := m.moduleErrorLength()
+
 	decoder := scale.NewDecoder(bytes.NewReader(e))
 
 	// determine number of events
@@ -312,7 +315,12 @@ func (e EventRecordsRaw) DecodeEventRecords(m *Metadata, t interface{}) error {
 
 		// set the remaining fields
 		for j := 1; j < numFields; j++ {
-			err = decoder.Decode(holder.Elem().FieldByIndex([]int{j}).Addr().Interface())
+			target := holder.Elem().FieldByIndex([]int{j}).Addr().Interface()
+			if d, ok := target.(dispatchErrorDecoder); ok {
+				err = d.decodeWithModuleErrorLength(*decoder, moduleErrorLength)
+			} else {
+				err = decoder.Decode(target)
+			}
 			if err != nil {
 				return fmt.Errorf("unable to decode field %v event #%v with EventID %v, field %v_%v: %v", j, i, id, moduleName,
 					eventName, err)
@@ -327,6 +335,12 @@ func (e EventRecordsRaw) DecodeEventRecords(m *Metadata, t interface{}) error {
 	return nil
 }
 
+// dispatchErrorDecoder is implemented by DispatchError and the types containing it, whose encoding depends on the
+// module error length of the runtime
+type dispatchErrorDecoder interface {
+	decodeWithModuleErrorLength(decoder scale.Decoder, moduleErrorLength int) error
+}
+
 // Phase is an enum describing the current phase of the event (applying the extrinsic or finalized)
 type Phase struct {
 	IsApplyExtrinsic bool
@@ -381,50 +395,4 @@ func (p Phase) Encode(encoder scale.Encoder) error {
 	return nil
 }
 
-// DispatchError is an error occurring during extrinsic dispatch
-type DispatchError struct {
-	HasModule bool
-	Module    uint8
-	Error     uint8
-}
-
-func (d *DispatchError) Decode(decoder scale.Decoder) error {
-	b, err := decoder.ReadOneByte()
-	if err != nil {
-		return err
-	}
-
-	// https://github.com/paritytech/substrate/blob/4da29261bfdc13057a425c1721aeb4ec68092d42/primitives/runtime/src/lib.rs
-	// Line 391
-	// Enum index 3 for Module Error
-	if b == 3 {
-		d.HasModule = true
-		err = decoder.Decode(&d.Module)
-	}
-	if err != nil {
-		return err
-	}
-
-	return decoder.Decode(&d.Error)
-}
-
-func (d DispatchError) Encode(encoder scale.Encoder) error {
-	var err error
-	if d.HasModule {
-		err = encoder.PushByte(3)
-		if err != nil {
-			return err
-		}
-		err = encoder.Encode(d.Module)
-	} else {
-		err = encoder.PushByte(0)
-	}
-
-	if err != nil {
-		return err
-	}
-
-	return encoder.Encode(&d.Error)
-}
-
 type EventID [2]byte
@@ -0,0 +1,126 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/scale"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// DynamicEventRecord is an event record that has been decoded using the type information of MetadataV14 instead of a
+// static Go struct, so events of pallets unknown to this library can be decoded as well.
+type DynamicEventRecord struct {
+	Phase   Phase
+	EventID EventID
+	Pallet  Text
+	Name    Text
+	Fields  []NamedValue
+	Topics  []Hash
+}
+
+// DecodeDynamicEventRecords decodes all event records from an EventRecordsRaw by walking the type registry of the
+// given Metadata m. Contrary to DecodeEventRecords, it does not need a target struct listing every event, but it
+// requires metadata version 14 or later.
+func (e EventRecordsRaw) DecodeDynamicEventRecords(m *Metadata) ([]DynamicEventRecord, error) {
+	if !m.IsMetadataV14 {
+		return nil, errors.New("dynamic event decoding requires metadata v14")
+	}
+	meta := &m.AsMetadataV14
+
+	log.Debug(fmt.Sprintf("will decode dynamic event records from raw hex: %#x", e))
+
+	decoder := scale.NewDecoder(bytes.NewReader(e))
+
+	// determine number of events
+	n, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug(fmt.Sprintf("found %v events", n))
+
+	// the number of events is read from the input, so preallocate no more records than there are bytes
+	capacity := n.Uint64()
+	if capacity > uint64(len(e)) {
+		capacity = uint64(len(e))
+	}
+	records := make([]DynamicEventRecord, 0, capacity)
+	for i := uint64(0); i < n.Uint64(); i++ {
+		var record DynamicEventRecord
+
+		err = decoder.Decode(&record.Phase)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode Phase for event #%v: %v", i, err)
+		}
+
+		err = decoder.Decode(&record.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode EventID for event #%v: %v", i, err)
+		}
+
+		pallet, variant, err := meta.findEventVariant(record.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find event with EventID %v in metadata for event #%v: %v",
+				record.EventID, i, err)
+		}
+		record.Pallet = pallet.Name
+		record.Name = variant.Name
+
+		log.Debug(fmt.Sprintf("event #%v is in module %v with event name %v", i, record.Pallet, record.Name))
+
+		record.Fields, err = meta.decodeFields(*decoder, variant.Fields)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode event #%v with EventID %v, %v.%v: %v", i, record.EventID,
+				record.Pallet, record.Name, err)
+		}
+
+		err = decoder.Decode(&record.Topics)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode Topics for event #%v: %v", i, err)
+		}
+
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// findEventVariant returns the pallet and the variant of its event enum for the given EventID
+func (d *MetadataV14) findEventVariant(eventID EventID) (*PalletMetadataV14, *Si1Variant, error) {
+	for i := range d.Pallets {
+		mod := &d.Pallets[i]
+		if !mod.HasEvents || uint8(mod.Index) != eventID[0] {
+			continue
+		}
+		typ, err := d.FindType(mod.Events.Type.Int64())
+		if err != nil {
+			return nil, nil, err
+		}
+		if !typ.Def.IsVariant {
+			return nil, nil, fmt.Errorf("event type of module %v is not a variant", mod.Name)
+		}
+		variant, err := findVariant(typ.Def.Variant, eventID[1])
+		if err != nil {
+			return nil, nil, fmt.Errorf("event of module %v: %v", mod.Name, err)
+		}
+		return mod, variant, nil
+	}
+	return nil, nil, fmt.Errorf("module index %v out of range", eventID[0])
+}
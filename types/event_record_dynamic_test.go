@@ -0,0 +1,88 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// System.ExtrinsicSuccess and Balances.Transfer (Alice to Bob, 1 DOT) in ApplyExtrinsic(1)
+var exampleDynamicEventRecords = EventRecordsRaw(MustHexDecodeString("0x08" +
+	"0001000000" + "0000" + "1027000000000000" + "0000" + "00" +
+	"0001000000" + "0502" +
+	"d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d" +
+	"8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48" +
+	"0010a5d4e80000000000000000000000" + "00"))
+
+func TestEventRecordsRaw_DecodeDynamicEventRecords(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	records, err := exampleDynamicEventRecords.DecodeDynamicEventRecords(&meta)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	success := records[0]
+	assert.Equal(t, Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 1}, success.Phase)
+	assert.Equal(t, Text("System"), success.Pallet)
+	assert.Equal(t, Text("ExtrinsicSuccess"), success.Name)
+	assert.Len(t, success.Fields, 1)
+	info := success.Fields[0].Value
+	assert.Equal(t, ValueKindComposite, info.Kind)
+	assert.Equal(t, Text("weight"), info.Fields[0].Name)
+	assert.Equal(t, U64(10000), info.Fields[0].Value.Primitive)
+	assert.Equal(t, Text("Normal"), info.Fields[1].Value.VariantName)
+	assert.Equal(t, Text("Yes"), info.Fields[2].Value.VariantName)
+	assert.Empty(t, success.Topics)
+
+	transfer := records[1]
+	assert.Equal(t, EventID{5, 2}, transfer.EventID)
+	assert.Equal(t, Text("Balances"), transfer.Pallet)
+	assert.Equal(t, Text("Transfer"), transfer.Name)
+	assert.Len(t, transfer.Fields, 3)
+	assert.Equal(t, Text("T::AccountId"), transfer.Fields[0].TypeName)
+	assert.Len(t, transfer.Fields[0].Value.Fields[0].Value.Items, 32)
+	assert.Equal(t, U8(0xd4), transfer.Fields[0].Value.Fields[0].Value.Items[0].Primitive)
+	assert.Equal(t, NewU128(*big.NewInt(1000000000000)), transfer.Fields[2].Value.Primitive)
+}
+
+func TestEventRecordsRaw_DecodeDynamicEventRecords_UnknownEvent(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	e := EventRecordsRaw(MustHexDecodeString("0x04000100000005ff00"))
+	_, err = e.DecodeDynamicEventRecords(&meta)
+	assert.EqualError(t, err, "unable to find event with EventID [5 255] in metadata for event #0: "+
+		"event of module Balances: variant index 255 not found")
+}
+
+func TestEventRecordsRaw_DecodeDynamicEventRecords_Corrupted(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	// a number of events of 2^62 - 1 fails on the missing input instead of allocating for them upfront
+	e := EventRecordsRaw(MustHexDecodeString("0x13ffffffffffffff3f"))
+	_, err = e.DecodeDynamicEventRecords(&meta)
+	assert.Error(t, err)
+}
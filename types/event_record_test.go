@@ -288,9 +288,43 @@ func TestEventRecordsRaw_Decode(t *testing.T) {
 	assert.Equal(t, exp, events)
 }
 
+func TestEventRecordsRaw_Decode_FourByteModuleErrors(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+	setFourByteModuleErrors(t, &meta)
+
+	e := EventRecordsRaw(MustHexDecodeString(
+		"0x04" + // (len 1) << 2
+
+			"0002000000" + // ApplyExtrinsic(2)
+			"0001" + // System_ExtrinsicFailed
+			"030b00010000" + // DispatchError Module(11, [0, 1, 0, 0]) with four byte errors
+			"1027000000000000" + // Weight
+			"01" + // Class Operational
+			"00" + // PaysFee Yes
+			"00", // Topics
+	))
+
+	events := struct {
+		System_ExtrinsicFailed []EventSystemExtrinsicFailed //nolint:stylecheck,golint
+	}{}
+	err = e.DecodeEventRecords(&meta, &events)
+	assert.NoError(t, err)
+
+	exp := []EventSystemExtrinsicFailed{{
+		Phase: Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: 2},
+		DispatchError: DispatchError{HasModule: true, Module: 11, Error: 0, HasErrorDetails: true,
+			ErrorDetails: [3]uint8{1, 0, 0}},
+		DispatchInfo: DispatchInfo{Weight: 10000, Class: DispatchClass{IsOperational: true}, PaysFee: Pays{IsYes: true}},
+		Topics:       []Hash(nil),
+	}}
+	assert.Equal(t, exp, events.System_ExtrinsicFailed)
+}
+
 func TestDispatchError(t *testing.T) {
 	assertRoundtrip(t, DispatchError{HasModule: true, Module: 0xf1, Error: 0xa2})
-	assertRoundtrip(t, DispatchError{HasModule: false, Error: 0xa2})
+	assertRoundtrip(t, DispatchError{IsOther: true})
 }
 
 func TestPhase(t *testing.T) {
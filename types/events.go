@@ -1420,7 +1420,13 @@ type DispatchResult struct {
 	Error DispatchError
 }
 
+// Decode implements decoding for DispatchResult, assuming module errors are encoded with a single byte
 func (d *DispatchResult) Decode(decoder scale.Decoder) error {
+	return d.decodeWithModuleErrorLength(decoder, 1)
+}
+
+// decodeWithModuleErrorLength decodes d with module errors being encoded with the given number of bytes, 1 or 4
+func (d *DispatchResult) decodeWithModuleErrorLength(decoder scale.Decoder, moduleErrorLength int) error {
 	b, err := decoder.ReadOneByte()
 	if err != nil {
 		return err
@@ -1432,7 +1438,7 @@ func (d *DispatchResult) Decode(decoder scale.Decoder) error {
 		return nil
 	default:
 		derr := DispatchError{}
-		err = decoder.Decode(&derr)
+		err = derr.decodeWithModuleErrorLength(decoder, moduleErrorLength)
 		if err != nil {
 			return err
 		}
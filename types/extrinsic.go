@@ -121,7 +121,7 @@ func (e Extrinsic) Type() uint8 {
 }
 
 // Sign adds a signature to the extrinsic
-func (e *Extrinsic) Sign(signer signature.KeyringPair, o SignatureOptions) error {
+func (e *Extrinsic) Sign(signer signature.Signer, o SignatureOptions) error {
 	if e.Type() != ExtrinsicVersion4 {
 		return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(), e.Type())
 	}
@@ -149,16 +149,16 @@ func (e *Extrinsic) Sign(signer signature.KeyringPair, o SignatureOptions) error
 		TransactionVersion: o.TransactionVersion,
 	}
 
-	signerPubKey := NewMultiAddressFromAccountID(signer.PublicKey)
+	signerPubKey := NewMultiAddressFromAccountID(signature.AccountID(signer.Public(), signer.SignatureType()))
 
-	sig, err := payload.Sign(signer)
+	sig, err := signMultiSignature(signer, payload)
 	if err != nil {
 		return err
 	}
 
 	extSig := ExtrinsicSignatureV4{
 		Signer:    signerPubKey,
-		Signature: MultiSignature{IsSr25519: true, AsSr25519: sig},
+		Signature: sig,
 		Era:       era,
 		Nonce:     o.Nonce,
 		Tip:       o.Tip,
@@ -172,6 +172,292 @@ func (e *Extrinsic) Sign(signer signature.KeyringPair, o SignatureOptions) error
 	return nil
 }
 
+// WithDummySignature returns a copy of the extrinsic signed by signer with a signature of zeros of the given type
+// instead of a real one. The copy has the same encoded length as the extrinsic signed for real, so it can be used to
+// estimate fees with payment_queryInfo, which does not check the signature, without access to the private key. With
+// metadata v14, the extra data is built from the signed extensions of the metadata like SignWithMetadata does, older
+// metadata uses the fixed layout of Sign.
+func (e Extrinsic) WithDummySignature(signer MultiAddress, t signature.SignatureType, m *Metadata,
+	o SignatureOptions) (Extrinsic, error) {
+	if e.Type() != ExtrinsicVersion4 {
+		return Extrinsic{}, fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version,
+			e.IsSigned(), e.Type())
+	}
+
+	sigLen := 64
+	if t == signature.SignatureTypeEcdsa {
+		sigLen = 65
+	}
+	sig, err := NewMultiSignature(t, make([]byte, sigLen))
+	if err != nil {
+		return Extrinsic{}, err
+	}
+
+	era := o.Era
+	if !o.Era.IsMortalEra {
+		era = ExtrinsicEra{IsImmortalEra: true}
+	}
+
+	e.Signature = ExtrinsicSignatureV4{
+		Signer:    signer,
+		Signature: sig,
+		Era:       era,
+		Nonce:     o.Nonce,
+		Tip:       o.Tip,
+	}
+	if m.IsMetadataV14 {
+		extra, _, err := m.AsMetadataV14.EncodeSignedExtensions(o)
+		if err != nil {
+			return Extrinsic{}, err
+		}
+		e.Signature.HasExtra = true
+		e.Signature.Extra = extra
+	}
+	e.Version |= ExtrinsicBitSigned
+	return e, nil
+}
+
+// SignWithMetadata adds a signature to the extrinsic, building the extra and additional signed data from the signed
+// extensions declared by the metadata instead of the fixed layout of ExtrinsicPayloadV4. This is required for chains
+// with extensions such as ChargeAssetTxPayment, CheckMetadataHash or custom ones, whose encoders can be registered
+// with RegisterSignedExtension.
+func (e *Extrinsic) SignWithMetadata(signer signature.Signer, m *Metadata, o SignatureOptions) error {
+	if e.Type() != ExtrinsicVersion4 {
+		return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(), e.Type())
+	}
+	if !m.IsMetadataV14 {
+		return fmt.Errorf("signing with signed extensions requires metadata v14")
+	}
+
+	mb, err := EncodeToBytes(e.Method)
+	if err != nil {
+		return err
+	}
+
+	era := o.Era
+	if !o.Era.IsMortalEra {
+		era = ExtrinsicEra{IsImmortalEra: true}
+	}
+
+	extra, additional, err := m.AsMetadataV14.EncodeSignedExtensions(o)
+	if err != nil {
+		return err
+	}
+
+	payload := ExtrinsicPayloadWithExtensions{
+		Method:           mb,
+		Extra:            extra,
+		AdditionalSigned: additional,
+	}
+
+	sig, err := signMultiSignature(signer, payload)
+	if err != nil {
+		return err
+	}
+
+	e.Signature = ExtrinsicSignatureV4{
+		Signer:    NewMultiAddressFromAccountID(signature.AccountID(signer.Public(), signer.SignatureType())),
+		Signature: sig,
+		Era:       era,
+		Nonce:     o.Nonce,
+		Tip:       o.Tip,
+		HasExtra:  true,
+		Extra:     extra,
+	}
+
+	// mark the extrinsic as signed
+	e.Version |= ExtrinsicBitSigned
+
+	return nil
+}
+
+// VerifySignature verifies the signature of a signed extrinsic against the public key of its signer without requiring
+// a private key, e.g. to re-validate extrinsics of historical blocks. It reconstructs the signing payload from the
+// extrinsic and the given genesis hash, block hash and runtime version, where blockHashForEra is the hash of the block
+// the era of the extrinsic starts at, or the genesis hash for immortal extrinsics. sr25519, ed25519 and ecdsa
+// signatures of signers given by their AccountID are supported.
+//
+// The payload is built with the fixed layout of ExtrinsicPayloadV4, i.e. the default signed extensions. Extrinsics of
+// chains with other signed extensions need VerifySignatureWithMetadata, since only the metadata declares them.
+func (e Extrinsic) VerifySignature(genesisHash Hash, blockHashForEra Hash, rv RuntimeVersion) (bool, error) {
+	return e.verifySignature(nil, genesisHash, blockHashForEra, rv)
+}
+
+// VerifySignatureWithMetadata is like VerifySignature, building the signing payload from the signed extensions
+// declared by the metadata. With metadata v14, extrinsics of chains with other than the default signed extensions must
+// have been decoded with DecodeExtrinsic. Older metadata only names the signed extensions, so an error is returned if
+// they differ from the default ones the payload is built from.
+func (e Extrinsic) VerifySignatureWithMetadata(m *Metadata, genesisHash Hash, blockHashForEra Hash,
+	rv RuntimeVersion) (bool, error) {
+	return e.verifySignature(m, genesisHash, blockHashForEra, rv)
+}
+
+// verifySignature verifies the signature of the extrinsic, with the signing payload being built from the metadata m
+// or with the default layout if m is nil
+func (e Extrinsic) verifySignature(m *Metadata, genesisHash Hash, blockHash Hash, rv RuntimeVersion) (bool, error) {
+	if !e.IsSigned() {
+		return false, fmt.Errorf("extrinsic is not signed")
+	}
+	if e.Type() != ExtrinsicVersion4 {
+		return false, fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(),
+			e.Type())
+	}
+	if !e.Signature.Signer.IsID {
+		return false, fmt.Errorf("unable to verify signature of a signer that is not given by its AccountID")
+	}
+
+	payload, err := e.signingPayload(m, SignatureOptions{
+		BlockHash:          blockHash,
+		Era:                e.Signature.Era,
+		GenesisHash:        genesisHash,
+		Nonce:              e.Signature.Nonce,
+		SpecVersion:        rv.SpecVersion,
+		Tip:                e.Signature.Tip,
+		TransactionVersion: rv.TransactionVersion,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	b, err := EncodeToBytes(payload)
+	if err != nil {
+		return false, err
+	}
+
+	accountID := e.Signature.Signer.AsID[:]
+	sig := e.Signature.Signature
+	switch {
+	case sig.IsSr25519:
+		return signature.VerifyWithPublicKey(b, sig.AsSr25519[:], accountID, signature.SignatureTypeSr25519)
+	case sig.IsEd25519:
+		return signature.VerifyWithPublicKey(b, sig.AsEd25519[:], accountID, signature.SignatureTypeEd25519)
+	case sig.IsEcdsa:
+		// the account id of ecdsa signers is the hash of their public key, which is recovered from the signature
+		pub, err := signature.RecoverEcdsaPublicKey(b, sig.AsEcdsa[:])
+		if err != nil {
+			return false, err
+		}
+		return bytes.Equal(signature.AccountID(pub, signature.SignatureTypeEcdsa), accountID), nil
+	default:
+		return false, fmt.Errorf("unsupported signature variant")
+	}
+}
+
+// defaultSignedExtensions are the signed extensions whose extra and additional signed data ExtrinsicPayloadV4 encodes,
+// in order. CheckWeight is left out since its data is empty.
+var defaultSignedExtensions = [][]string{
+	{"CheckSpecVersion"}, {"CheckTxVersion"}, {"CheckGenesis"}, {"CheckMortality", "CheckEra"}, {"CheckNonce"},
+	{"ChargeTransactionPayment"},
+}
+
+// signingPayload returns the payload the signer of the extrinsic has signed, given the options the extrinsic has been
+// signed with. Without metadata, the payload has the default layout of ExtrinsicPayloadV4.
+func (e Extrinsic) signingPayload(m *Metadata, o SignatureOptions) (interface{}, error) {
+	mb, err := EncodeToBytes(e.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	if m != nil && m.IsMetadataV14 {
+		extra, additional, err := m.AsMetadataV14.EncodeSignedExtensions(o)
+		if err != nil {
+			return nil, err
+		}
+
+		if e.Signature.HasExtra {
+			extra = e.Signature.Extra
+		} else {
+			// the extrinsic has been decoded assuming the default signed extensions
+			defaultExtra, err := EncodeToBytes(struct {
+				Era   ExtrinsicEra
+				Nonce UCompact
+				Tip   UCompact
+			}{o.Era, o.Nonce, o.Tip})
+			if err != nil {
+				return nil, err
+			}
+			if !bytes.Equal(extra, defaultExtra) {
+				return nil, fmt.Errorf("signed extensions of the metadata differ from the default ones, decode the " +
+					"extrinsic with DecodeExtrinsic")
+			}
+		}
+
+		return ExtrinsicPayloadWithExtensions{Method: mb, Extra: extra, AdditionalSigned: additional}, nil
+	}
+
+	if e.Signature.HasExtra {
+		return nil, fmt.Errorf("verifying an extrinsic signed with signed extensions requires metadata v14")
+	}
+
+	var names []string
+	if m != nil {
+		switch {
+		case m.IsMetadataV11:
+			names = m.AsMetadataV11.Extrinsic.SignedExtensions
+		case m.IsMetadataV12:
+			names = m.AsMetadataV12.Extrinsic.SignedExtensions
+		case m.IsMetadataV13:
+			names = m.AsMetadataV13.Extrinsic.SignedExtensions
+		}
+	}
+	if names != nil && !isDefaultSignedExtensions(names) {
+		return nil, fmt.Errorf("signed extensions %v differ from the default ones, verifying requires metadata v14",
+			names)
+	}
+
+	return ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: ExtrinsicPayloadV3{
+			Method:      mb,
+			Era:         o.Era,
+			Nonce:       o.Nonce,
+			Tip:         o.Tip,
+			SpecVersion: o.SpecVersion,
+			GenesisHash: o.GenesisHash,
+			BlockHash:   o.BlockHash,
+		},
+		TransactionVersion: o.TransactionVersion,
+	}, nil
+}
+
+// isDefaultSignedExtensions returns true if names are the defaultSignedExtensions, with or without CheckWeight
+func isDefaultSignedExtensions(names []string) bool {
+	i := 0
+	for _, name := range names {
+		if name == "CheckWeight" {
+			continue
+		}
+		if i == len(defaultSignedExtensions) || !containsString(defaultSignedExtensions[i], name) {
+			return false
+		}
+		i++
+	}
+	return i == len(defaultSignedExtensions)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// signMultiSignature signs the encoded payload and wraps the signature into the MultiSignature variant of the signer
+func signMultiSignature(signer signature.Signer, payload interface{}) (MultiSignature, error) {
+	b, err := EncodeToBytes(payload)
+	if err != nil {
+		return MultiSignature{}, err
+	}
+
+	sig, err := signPayload(signer, b)
+	if err != nil {
+		return MultiSignature{}, err
+	}
+
+	return NewMultiSignature(signer.SignatureType(), sig)
+}
+
 func (e *Extrinsic) Decode(decoder scale.Decoder) error {
 	// compact length encoding (1, 2, or 4 bytes) (may not be there for Extrinsics older than Jan 11 2019)
 	_, err := decoder.DecodeUintCompact()
@@ -207,6 +493,55 @@ func (e *Extrinsic) Decode(decoder scale.Decoder) error {
 	return nil
 }
 
+// DecodeExtrinsic decodes an encoded extrinsic whose signature carries the extra data of the signed extensions the
+// metadata declares. Unlike Decode, which assumes the default signed extensions, it supports chains with extensions
+// such as ChargeAssetTxPayment, CheckMetadataHash or custom ones. The decoded extrinsic re-encodes to bz.
+func DecodeExtrinsic(m *Metadata, bz []byte) (Extrinsic, error) {
+	if !m.IsMetadataV14 {
+		return Extrinsic{}, fmt.Errorf("decoding signed extensions requires metadata v14")
+	}
+
+	decoder := scale.NewDecoder(bytes.NewReader(bz))
+
+	// compact length encoding
+	_, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return Extrinsic{}, err
+	}
+
+	var e Extrinsic
+	err = decoder.Decode(&e.Version)
+	if err != nil {
+		return Extrinsic{}, err
+	}
+
+	if e.IsSigned() {
+		if e.Type() != ExtrinsicVersion4 {
+			return Extrinsic{}, fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version,
+				e.IsSigned(), e.Type())
+		}
+
+		err = decoder.Decode(&e.Signature.Signer)
+		if err != nil {
+			return Extrinsic{}, err
+		}
+		err = decoder.Decode(&e.Signature.Signature)
+		if err != nil {
+			return Extrinsic{}, err
+		}
+		err = m.AsMetadataV14.DecodeSignedExtensions(*decoder, &e.Signature)
+		if err != nil {
+			return Extrinsic{}, err
+		}
+	}
+
+	err = decoder.Decode(&e.Method)
+	if err != nil {
+		return Extrinsic{}, err
+	}
+	return e, nil
+}
+
 func (e Extrinsic) Encode(encoder scale.Encoder) error {
 	if e.Type() != ExtrinsicVersion4 {
 		return fmt.Errorf("unsupported extrinsic version: %v (isSigned: %v, type: %v)", e.Version, e.IsSigned(),
@@ -278,6 +613,57 @@ func NewCall(m *Metadata, call string, args ...interface{}) (Call, error) {
 	return Call{c, a}, nil
 }
 
+// NewCallFromArgs creates a Call for the given "Pallet.call" from arguments keyed by their name in the metadata. Each
+// argument is encoded according to the type the call declares for it (see MetadataV14.NewValue for the accepted Go
+// values), so for example a uint64 for a Compact<u128> balance is compact encoded. It requires metadata v14.
+func NewCallFromArgs(m *Metadata, call string, args map[string]interface{}) (Call, error) {
+	if !m.IsMetadataV14 {
+		return Call{}, fmt.Errorf("building calls from args requires metadata v14")
+	}
+	meta := &m.AsMetadataV14
+
+	mod, variant, err := meta.findCallVariant(call)
+	if err != nil {
+		return Call{}, err
+	}
+
+	var buffer = bytes.Buffer{}
+	encoder := scale.NewEncoder(&buffer)
+	for _, f := range variant.Fields {
+		arg, ok := args[string(f.Name)]
+		if !ok {
+			return Call{}, fmt.Errorf("missing argument %v (%v) for call %v", f.Name, f.TypeName, call)
+		}
+		v, err := meta.NewValue(f.Type.Int64(), arg)
+		if err != nil {
+			return Call{}, fmt.Errorf("invalid argument %v (%v) for call %v: %v", f.Name, f.TypeName, call, err)
+		}
+		err = meta.EncodeValue(*encoder, f.Type.Int64(), v)
+		if err != nil {
+			return Call{}, fmt.Errorf("unable to encode argument %v (%v) for call %v: %v", f.Name, f.TypeName, call,
+				err)
+		}
+	}
+	if len(args) != len(variant.Fields) {
+		for name := range args {
+			if !hasField(variant.Fields, name) {
+				return Call{}, fmt.Errorf("unknown argument %v for call %v", name, call)
+			}
+		}
+	}
+
+	return Call{CallIndex{uint8(mod.Index), uint8(variant.Index)}, buffer.Bytes()}, nil
+}
+
+func hasField(fields []Si1Field, name string) bool {
+	for _, f := range fields {
+		if string(f.Name) == name {
+			return true
+		}
+	}
+	return false
+}
+
 // Callindex is a 16 bit wrapper around the `[sectionIndex, methodIndex]` value that uniquely identifies a method
 type CallIndex struct {
 	SectionIndex uint8
@@ -21,6 +21,7 @@ import (
 
 	"github.com/JFJun/go-substrate-rpc-client/v3/scale"
 	"github.com/JFJun/go-substrate-rpc-client/v3/signature"
+	"golang.org/x/crypto/blake2b"
 )
 
 // ExtrinsicPayloadV3 is a signing payload for an Extrinsic. For the final encoding, it is variable length based on
@@ -38,14 +39,14 @@ type ExtrinsicPayloadV3 struct {
 	BlockHash   Hash         // additional via system::CheckEra
 }
 
-// Sign the extrinsic payload with the given derivation path
-func (e ExtrinsicPayloadV3) Sign(signer signature.KeyringPair) (Signature, error) {
+// Sign the extrinsic payload with the given signer
+func (e ExtrinsicPayloadV3) Sign(signer signature.Signer) (Signature, error) {
 	b, err := EncodeToBytes(e)
 	if err != nil {
 		return Signature{}, err
 	}
 
-	sig, err := signature.Sign(b, signer.URI)
+	sig, err := signPayload(signer, b)
 	return NewSignature(sig), err
 }
 
@@ -100,14 +101,14 @@ type ExtrinsicPayloadV4 struct {
 	TransactionVersion U32
 }
 
-// Sign the extrinsic payload with the given derivation path
-func (e ExtrinsicPayloadV4) Sign(signer signature.KeyringPair) (Signature, error) {
+// Sign the extrinsic payload with the given signer
+func (e ExtrinsicPayloadV4) Sign(signer signature.Signer) (Signature, error) {
 	b, err := EncodeToBytes(e)
 	if err != nil {
 		return Signature{}, err
 	}
 
-	sig, err := signature.Sign(b, signer.URI)
+	sig, err := signPayload(signer, b)
 	return NewSignature(sig), err
 }
 
@@ -159,3 +160,37 @@ func (e ExtrinsicPayloadV4) Encode(encoder scale.Encoder) error {
 func (e *ExtrinsicPayloadV4) Decode(decoder scale.Decoder) error {
 	return fmt.Errorf("decoding of ExtrinsicPayloadV4 is not supported")
 }
+
+// ExtrinsicPayloadWithExtensions is a signing payload whose extra and additional signed data has been encoded from the
+// signed extensions of the metadata, see MetadataV14.EncodeSignedExtensions
+type ExtrinsicPayloadWithExtensions struct {
+	Method           BytesBare
+	Extra            BytesBare
+	AdditionalSigned BytesBare
+}
+
+// Sign the extrinsic payload with the given signer
+func (e ExtrinsicPayloadWithExtensions) Sign(signer signature.Signer) (Signature, error) {
+	b, err := EncodeToBytes(e)
+	if err != nil {
+		return Signature{}, err
+	}
+
+	sig, err := signPayload(signer, b)
+	return NewSignature(sig), err
+}
+
+// Decode does nothing and always returns an error. ExtrinsicPayloadWithExtensions is only used for encoding, not for
+// decoding
+func (e *ExtrinsicPayloadWithExtensions) Decode(decoder scale.Decoder) error {
+	return fmt.Errorf("decoding of ExtrinsicPayloadWithExtensions is not supported")
+}
+
+// signPayload signs an encoded payload, hashing it first if it is longer than 256 bytes as the runtime expects
+func signPayload(signer signature.Signer, payload []byte) ([]byte, error) {
+	if len(payload) > 256 {
+		h := blake2b.Sum256(payload)
+		payload = h[:]
+	}
+	return signer.Sign(payload)
+}
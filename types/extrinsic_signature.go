@@ -16,6 +16,8 @@
 
 package types
 
+import "github.com/JFJun/go-substrate-rpc-client/v3/scale"
+
 type ExtrinsicSignatureV3 struct {
 	Signer    Address
 	Signature Signature
@@ -30,6 +32,66 @@ type ExtrinsicSignatureV4 struct {
 	Era       ExtrinsicEra // extra via system::CheckEra
 	Nonce     UCompact     // extra via system::CheckNonce (Compact<Index> where Index is u32))
 	Tip       UCompact     // extra via balances::TakeFees (Compact<Balance> where Balance is u128))
+	// HasExtra is true if the extrinsic has been signed with Extrinsic.SignWithMetadata or decoded with
+	// DecodeExtrinsic, in which case Extra is encoded instead of Era, Nonce and Tip
+	HasExtra bool
+	// Extra is the encoded extra data of all signed extensions the metadata declares, which may be empty
+	Extra BytesBare
+}
+
+func (s ExtrinsicSignatureV4) Encode(encoder scale.Encoder) error {
+	err := encoder.Encode(s.Signer)
+	if err != nil {
+		return err
+	}
+
+	err = encoder.Encode(s.Signature)
+	if err != nil {
+		return err
+	}
+
+	if s.HasExtra {
+		return encoder.Encode(s.Extra)
+	}
+
+	err = encoder.Encode(s.Era)
+	if err != nil {
+		return err
+	}
+
+	err = encoder.Encode(s.Nonce)
+	if err != nil {
+		return err
+	}
+
+	return encoder.Encode(s.Tip)
+}
+
+// Decode implements decoding for ExtrinsicSignatureV4 assuming the default signed extensions, whose extra data is Era,
+// Nonce and Tip. Signatures of chains with other signed extensions must be decoded with DecodeExtrinsic, which fills
+// Extra from the signed extensions of the metadata.
+func (s *ExtrinsicSignatureV4) Decode(decoder scale.Decoder) error {
+	err := decoder.Decode(&s.Signer)
+	if err != nil {
+		return err
+	}
+
+	err = decoder.Decode(&s.Signature)
+	if err != nil {
+		return err
+	}
+
+	err = decoder.Decode(&s.Era)
+	if err != nil {
+		return err
+	}
+
+	err = decoder.Decode(&s.Nonce)
+	if err != nil {
+		return err
+	}
+
+	return decoder.Decode(&s.Tip)
 }
 
 type SignatureOptions struct {
@@ -17,6 +17,7 @@
 package types_test
 
 import (
+	"crypto/ed25519"
 	"fmt"
 	"testing"
 
@@ -141,6 +142,456 @@ func TestExtrinsic_Sign(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestExtrinsic_SignWithMetadata(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	c, err := NewCallFromArgs(&meta, "Balances.transfer", map[string]interface{}{
+		"dest": map[string]interface{}{
+			"Id": MustHexDecodeString("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48"),
+		},
+		"value": 6969,
+	})
+	assert.NoError(t, err)
+
+	ext := NewExtrinsic(c)
+
+	o := SignatureOptions{
+		BlockHash:          NewHash(MustHexDecodeString("0xec7afaf1cca720ce88c1d1b689d81f0583cc15a97d621cf046dd9abf605ef22f")),
+		Era:                ExtrinsicEra{IsMortalEra: true, AsMortalEra: MortalEra{First: 0x15, Second: 0x02}},
+		GenesisHash:        NewHash(MustHexDecodeString("0xdcd1346701ca8396496e52aa2785b1748deb6db09551b72159dcb3e08991025b")),
+		Nonce:              NewUCompactFromUInt(1),
+		SpecVersion:        9110,
+		Tip:                NewUCompactFromUInt(2),
+		TransactionVersion: 8,
+	}
+
+	err = ext.SignWithMetadata(signature.TestKeyringPairAlice, &meta, o)
+	assert.NoError(t, err)
+	assert.True(t, ext.IsSigned())
+
+	extEnc, err := EncodeToHexString(ext)
+	assert.NoError(t, err)
+
+	var extDec Extrinsic
+	err = DecodeFromHexString(extEnc, &extDec)
+	assert.NoError(t, err)
+
+	assert.Equal(t, o.Era, extDec.Signature.Era)
+	assert.Equal(t, o.Nonce, extDec.Signature.Nonce)
+	assert.Equal(t, o.Tip, extDec.Signature.Tip)
+	assert.Equal(t, c, extDec.Method)
+
+	// polkadot only uses the default signed extensions, so the payload matches ExtrinsicPayloadV4
+	mb, err := EncodeToBytes(extDec.Method)
+	assert.NoError(t, err)
+	verifyPayload := ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: ExtrinsicPayloadV3{
+			Method:      mb,
+			Era:         extDec.Signature.Era,
+			Nonce:       extDec.Signature.Nonce,
+			Tip:         extDec.Signature.Tip,
+			SpecVersion: o.SpecVersion,
+			GenesisHash: o.GenesisHash,
+			BlockHash:   o.BlockHash,
+		},
+		TransactionVersion: o.TransactionVersion,
+	}
+
+	b, err := EncodeToBytes(verifyPayload)
+	assert.NoError(t, err)
+	ok, err := signature.Verify(b, extDec.Signature.Signature.AsSr25519[:], signature.TestKeyringPairAlice.URI)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestDecodeExtrinsic(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	c, err := NewCallFromArgs(&meta, "System.remark", map[string]interface{}{"remark": []byte{1, 2, 3}})
+	assert.NoError(t, err)
+
+	o := exampleSignatureOptions
+	o.Era = ExtrinsicEra{IsMortalEra: true, AsMortalEra: MortalEra{First: 0x15, Second: 0x02}}
+
+	exts := meta.AsMetadataV14.Extrinsic.SignedExtensions
+	empty := exts[0].Type
+	u32 := exts[0].AdditionalSigned
+	RegisterSignedExtension("CheckDecodeTestExtension", func(o SignatureOptions) (interface{}, interface{}, error) {
+		return 7, nil, nil
+	})
+
+	for _, test := range []struct {
+		name  string
+		exts  []SignedExtensionMetadataV14
+		extra []byte
+	}{
+		{"default", exts, MustHexDecodeString("0x15020408")},
+		{"custom", append(append([]SignedExtensionMetadataV14{}, exts...), SignedExtensionMetadataV14{
+			Identifier: "CheckDecodeTestExtension", Type: u32, AdditionalSigned: empty,
+		}), MustHexDecodeString("0x1502040807000000")},
+		{"empty", []SignedExtensionMetadataV14{
+			{Identifier: "CheckEmptyTestExtension", Type: empty, AdditionalSigned: empty},
+		}, []byte{}},
+	} {
+		meta.AsMetadataV14.Extrinsic.SignedExtensions = test.exts
+
+		ext := NewExtrinsic(c)
+		err = ext.SignWithMetadata(signature.TestKeyringPairAlice, &meta, o)
+		assert.NoError(t, err)
+		enc, err := EncodeToBytes(ext)
+		assert.NoError(t, err)
+
+		dec, err := DecodeExtrinsic(&meta, enc)
+		assert.NoError(t, err, test.name)
+		assert.True(t, dec.Signature.HasExtra, test.name)
+		assert.Equal(t, BytesBare(test.extra), dec.Signature.Extra, test.name)
+		assert.Equal(t, c, dec.Method, test.name)
+		if test.name != "empty" {
+			assert.Equal(t, o.Era, dec.Signature.Era, test.name)
+			assert.Equal(t, o.Nonce, dec.Signature.Nonce, test.name)
+			assert.Equal(t, o.Tip, dec.Signature.Tip, test.name)
+		}
+
+		reenc, err := EncodeToBytes(dec)
+		assert.NoError(t, err)
+		assert.Equal(t, enc, reenc, test.name)
+	}
+}
+
+type ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+func (s ed25519Signer) Public() []byte {
+	return s.key.Public().(ed25519.PublicKey)
+}
+
+func (s ed25519Signer) Sign(msg []byte) ([]byte, error) {
+	return ed25519.Sign(s.key, msg), nil
+}
+
+func (s ed25519Signer) SignatureType() signature.SignatureType {
+	return signature.SignatureTypeEd25519
+}
+
+func TestExtrinsic_Sign_CustomSigner(t *testing.T) {
+	signer := ed25519Signer{key: ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))}
+
+	c, err := NewCall(ExamplaryMetadataV4, "balances.transfer",
+		NewAddressFromAccountID(MustHexDecodeString("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")),
+		NewUCompactFromUInt(6969))
+	assert.NoError(t, err)
+
+	ext := NewExtrinsic(c)
+	o := SignatureOptions{
+		BlockHash:   NewHash(MustHexDecodeString("0x223e3eb79416e6258d262b3a76e827aa0886b884a96bf96395cdd1c52d0eeb45")),
+		Era:         ExtrinsicEra{IsImmortalEra: true},
+		GenesisHash: NewHash(MustHexDecodeString("0x81ad0bfe2a0bccd91d2e89852d79b7ff696d4714758e5f7c6f17ec7527e1f550")),
+		Nonce:       NewUCompactFromUInt(0),
+		SpecVersion: 170,
+		Tip:         NewUCompactFromUInt(0),
+	}
+
+	err = ext.Sign(signer, o)
+	assert.NoError(t, err)
+
+	assert.Equal(t, NewMultiAddressFromAccountID(signer.Public()), ext.Signature.Signer)
+	assert.True(t, ext.Signature.Signature.IsEd25519)
+	assert.False(t, ext.Signature.Signature.IsSr25519)
+
+	mb, err := EncodeToBytes(ext.Method)
+	assert.NoError(t, err)
+	b, err := EncodeToBytes(ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: ExtrinsicPayloadV3{
+			Method:      mb,
+			Era:         o.Era,
+			Nonce:       o.Nonce,
+			Tip:         o.Tip,
+			SpecVersion: o.SpecVersion,
+			GenesisHash: o.GenesisHash,
+			BlockHash:   o.BlockHash,
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, ed25519.Verify(signer.Public(), b, ext.Signature.Signature.AsEd25519[:]))
+}
+
+func TestExtrinsic_Sign_Ecdsa(t *testing.T) {
+	signer, err := signature.KeyringPairFromSecretWithType("//Alice", 42, signature.SignatureTypeEcdsa)
+	assert.NoError(t, err)
+
+	c, err := NewCall(ExamplaryMetadataV4, "balances.transfer",
+		NewAddressFromAccountID(MustHexDecodeString("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")),
+		NewUCompactFromUInt(6969))
+	assert.NoError(t, err)
+
+	ext := NewExtrinsic(c)
+	o := SignatureOptions{
+		BlockHash:   NewHash(MustHexDecodeString("0x223e3eb79416e6258d262b3a76e827aa0886b884a96bf96395cdd1c52d0eeb45")),
+		Era:         ExtrinsicEra{IsImmortalEra: true},
+		GenesisHash: NewHash(MustHexDecodeString("0x81ad0bfe2a0bccd91d2e89852d79b7ff696d4714758e5f7c6f17ec7527e1f550")),
+		Nonce:       NewUCompactFromUInt(0),
+		SpecVersion: 170,
+		Tip:         NewUCompactFromUInt(0),
+	}
+
+	err = ext.Sign(signer, o)
+	assert.NoError(t, err)
+
+	// the account id of ecdsa keys is the blake2_256 hash of the compressed public key
+	assert.Equal(t, NewMultiAddressFromAccountID(signer.AccountID()), ext.Signature.Signer)
+	assert.True(t, ext.Signature.Signature.IsEcdsa)
+
+	// the signature is encoded as the variant index followed by the bare 65 bytes
+	sigEnc, err := EncodeToBytes(ext.Signature.Signature)
+	assert.NoError(t, err)
+	assert.Len(t, sigEnc, 66)
+	assert.Equal(t, append([]byte{2}, ext.Signature.Signature.AsEcdsa[:]...), sigEnc)
+
+	extEnc, err := EncodeToHexString(ext)
+	assert.NoError(t, err)
+
+	var extDec Extrinsic
+	err = DecodeFromHexString(extEnc, &extDec)
+	assert.NoError(t, err)
+	assert.Equal(t, ext.Signature.Signature, extDec.Signature.Signature)
+
+	mb, err := EncodeToBytes(ext.Method)
+	assert.NoError(t, err)
+	b, err := EncodeToBytes(ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: ExtrinsicPayloadV3{
+			Method:      mb,
+			Era:         o.Era,
+			Nonce:       o.Nonce,
+			Tip:         o.Tip,
+			SpecVersion: o.SpecVersion,
+			GenesisHash: o.GenesisHash,
+			BlockHash:   o.BlockHash,
+		},
+	})
+	assert.NoError(t, err)
+	ok, err := signature.VerifyWithType(b, ext.Signature.Signature.AsEcdsa[:], signer.URI, signature.SignatureTypeEcdsa)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestExtrinsic_WithDummySignature(t *testing.T) {
+	c, err := NewCall(ExamplaryMetadataV4, "balances.transfer", NewAddressFromAccountID(MustHexDecodeString(
+		"0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")), NewUCompactFromUInt(6969))
+	assert.NoError(t, err)
+	o := SignatureOptions{Nonce: NewUCompactFromUInt(1), Tip: NewUCompactFromUInt(2), SpecVersion: 123}
+
+	for _, typ := range []signature.SignatureType{signature.SignatureTypeSr25519, signature.SignatureTypeEd25519,
+		signature.SignatureTypeEcdsa} {
+		signer, err := signature.KeyringPairFromSecretWithType("//Alice", 42, typ)
+		assert.NoError(t, err)
+		sender := NewMultiAddressFromAccountID(signature.AccountID(signer.PublicKey, typ))
+
+		ext := NewExtrinsic(c)
+		dummy, err := ext.WithDummySignature(sender, typ, ExamplaryMetadataV4, o)
+		assert.NoError(t, err)
+		assert.True(t, dummy.IsSigned())
+		assert.False(t, ext.IsSigned())
+
+		err = ext.Sign(signer, o)
+		assert.NoError(t, err)
+
+		dummyEnc, err := EncodeToBytes(dummy)
+		assert.NoError(t, err)
+		extEnc, err := EncodeToBytes(ext)
+		assert.NoError(t, err)
+		assert.Equal(t, len(extEnc), len(dummyEnc), typ.String())
+		assert.Equal(t, ext.Signature.Signer, dummy.Signature.Signer, typ.String())
+	}
+}
+
+func TestExtrinsic_WithDummySignature_SignedExtensions(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	c, err := NewCallFromArgs(&meta, "System.remark", map[string]interface{}{"remark": []byte{1, 2, 3}})
+	assert.NoError(t, err)
+
+	exts := meta.AsMetadataV14.Extrinsic.SignedExtensions
+	meta.AsMetadataV14.Extrinsic.SignedExtensions = append(append([]SignedExtensionMetadataV14{}, exts...),
+		SignedExtensionMetadataV14{Identifier: "CheckDummyTestExtension", Type: exts[0].AdditionalSigned,
+			AdditionalSigned: exts[0].Type})
+	RegisterSignedExtension("CheckDummyTestExtension", func(o SignatureOptions) (interface{}, interface{}, error) {
+		return 7, nil, nil
+	})
+
+	signer, err := signature.KeyringPairFromSecretWithType("//Alice", 42, signature.SignatureTypeEcdsa)
+	assert.NoError(t, err)
+	sender := NewMultiAddressFromAccountID(signature.AccountID(signer.PublicKey, signature.SignatureTypeEcdsa))
+
+	ext := NewExtrinsic(c)
+	dummy, err := ext.WithDummySignature(sender, signature.SignatureTypeEcdsa, &meta, exampleSignatureOptions)
+	assert.NoError(t, err)
+	err = ext.SignWithMetadata(signer, &meta, exampleSignatureOptions)
+	assert.NoError(t, err)
+
+	assert.True(t, dummy.Signature.HasExtra)
+	assert.Equal(t, ext.Signature.Extra, dummy.Signature.Extra)
+	assert.True(t, dummy.Signature.Signature.IsEcdsa)
+
+	dummyEnc, err := EncodeToBytes(dummy)
+	assert.NoError(t, err)
+	extEnc, err := EncodeToBytes(ext)
+	assert.NoError(t, err)
+	assert.Equal(t, len(extEnc), len(dummyEnc))
+
+	// the dummy decodes like the real extrinsic
+	dec, err := DecodeExtrinsic(&meta, dummyEnc)
+	assert.NoError(t, err)
+	assert.Equal(t, dummy.Signature.Extra, dec.Signature.Extra)
+}
+
+func TestExtrinsic_VerifySignature(t *testing.T) {
+	c, err := NewCall(ExamplaryMetadataV4, "balances.transfer",
+		NewAddressFromAccountID(MustHexDecodeString("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")),
+		NewUCompactFromUInt(6969))
+	assert.NoError(t, err)
+
+	genesisHash := NewHash(MustHexDecodeString("0x81ad0bfe2a0bccd91d2e89852d79b7ff696d4714758e5f7c6f17ec7527e1f550"))
+	blockHash := NewHash(MustHexDecodeString("0x223e3eb79416e6258d262b3a76e827aa0886b884a96bf96395cdd1c52d0eeb45"))
+	rv := RuntimeVersion{SpecVersion: 170, TransactionVersion: 3}
+
+	for _, typ := range []signature.SignatureType{signature.SignatureTypeSr25519, signature.SignatureTypeEd25519,
+		signature.SignatureTypeEcdsa} {
+		signer, err := signature.KeyringPairFromSecretWithType("//Alice", 42, typ)
+		assert.NoError(t, err)
+
+		ext := NewExtrinsic(c)
+		err = ext.Sign(signer, SignatureOptions{
+			BlockHash:          blockHash,
+			Era:                ExtrinsicEra{IsMortalEra: true, AsMortalEra: MortalEra{0x95, 0x00}},
+			GenesisHash:        genesisHash,
+			Nonce:              NewUCompactFromUInt(1),
+			SpecVersion:        rv.SpecVersion,
+			Tip:                NewUCompactFromUInt(0),
+			TransactionVersion: rv.TransactionVersion,
+		})
+		assert.NoError(t, err)
+
+		extEnc, err := EncodeToHexString(ext)
+		assert.NoError(t, err)
+
+		var extDec Extrinsic
+		err = DecodeFromHexString(extEnc, &extDec)
+		assert.NoError(t, err)
+
+		ok, err := extDec.VerifySignature(genesisHash, blockHash, rv)
+		assert.NoError(t, err)
+		assert.True(t, ok, typ.String())
+
+		ok, err = extDec.VerifySignature(genesisHash, genesisHash, rv)
+		assert.NoError(t, err)
+		assert.False(t, ok, typ.String())
+	}
+}
+
+func TestExtrinsic_VerifySignature_SignedExtensions(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	c, err := NewCallFromArgs(&meta, "System.remark", map[string]interface{}{"remark": []byte{1, 2, 3}})
+	assert.NoError(t, err)
+
+	o := exampleSignatureOptions
+	o.Era = ExtrinsicEra{IsMortalEra: true, AsMortalEra: MortalEra{First: 0x15, Second: 0x02}}
+	rv := RuntimeVersion{SpecVersion: o.SpecVersion, TransactionVersion: o.TransactionVersion}
+
+	// polkadot only uses the default signed extensions, so extrinsics decoded without metadata can be verified
+	ext := NewExtrinsic(c)
+	err = ext.SignWithMetadata(signature.TestKeyringPairAlice, &meta, o)
+	assert.NoError(t, err)
+	enc, err := EncodeToHexString(ext)
+	assert.NoError(t, err)
+	var extDec Extrinsic
+	err = DecodeFromHexString(enc, &extDec)
+	assert.NoError(t, err)
+	ok, err := extDec.VerifySignatureWithMetadata(&meta, o.GenesisHash, o.BlockHash, rv)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// with a custom extension, the extra data must be decoded with the metadata
+	exts := meta.AsMetadataV14.Extrinsic.SignedExtensions
+	meta.AsMetadataV14.Extrinsic.SignedExtensions = append(append([]SignedExtensionMetadataV14{}, exts...),
+		SignedExtensionMetadataV14{Identifier: "CheckVerifyTestExtension", Type: exts[0].AdditionalSigned,
+			AdditionalSigned: exts[0].AdditionalSigned})
+	RegisterSignedExtension("CheckVerifyTestExtension", func(o SignatureOptions) (interface{}, interface{}, error) {
+		return 7, 8, nil
+	})
+
+	ext = NewExtrinsic(c)
+	err = ext.SignWithMetadata(signature.TestKeyringPairAlice, &meta, o)
+	assert.NoError(t, err)
+	encBytes, err := EncodeToBytes(ext)
+	assert.NoError(t, err)
+
+	extDec, err = DecodeExtrinsic(&meta, encBytes)
+	assert.NoError(t, err)
+	ok, err = extDec.VerifySignatureWithMetadata(&meta, o.GenesisHash, o.BlockHash, rv)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = extDec.VerifySignatureWithMetadata(&meta, o.GenesisHash, o.GenesisHash, rv)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// without metadata, only the default layout can be verified
+	_, err = extDec.VerifySignature(o.GenesisHash, o.BlockHash, rv)
+	assert.EqualError(t, err, "verifying an extrinsic signed with signed extensions requires metadata v14")
+
+	extDec.Signature.HasExtra = false
+	_, err = extDec.VerifySignatureWithMetadata(&meta, o.GenesisHash, o.BlockHash, rv)
+	assert.EqualError(t, err, "signed extensions of the metadata differ from the default ones, decode the "+
+		"extrinsic with DecodeExtrinsic")
+}
+
+func TestExtrinsic_VerifySignature_SignedExtensionNames(t *testing.T) {
+	c, err := NewCall(ExamplaryMetadataV4, "balances.transfer",
+		NewAddressFromAccountID(MustHexDecodeString("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")),
+		NewUCompactFromUInt(6969))
+	assert.NoError(t, err)
+
+	o := exampleSignatureOptions
+	rv := RuntimeVersion{SpecVersion: o.SpecVersion, TransactionVersion: o.TransactionVersion}
+	ext := NewExtrinsic(c)
+	err = ext.Sign(signature.TestKeyringPairAlice, o)
+	assert.NoError(t, err)
+
+	meta := Metadata{IsMetadataV12: true, AsMetadataV12: MetadataV12{Extrinsic: ExtrinsicV11{SignedExtensions: []string{
+		"CheckSpecVersion", "CheckTxVersion", "CheckGenesis", "CheckMortality", "CheckNonce", "CheckWeight",
+		"ChargeTransactionPayment",
+	}}}}
+	ok, err := ext.VerifySignatureWithMetadata(&meta, o.GenesisHash, o.BlockHash, rv)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	meta.AsMetadataV12.Extrinsic.SignedExtensions = append(meta.AsMetadataV12.Extrinsic.SignedExtensions,
+		"ChargeAssetTxPayment")
+	_, err = ext.VerifySignatureWithMetadata(&meta, o.GenesisHash, o.BlockHash, rv)
+	assert.EqualError(t, err, "signed extensions [CheckSpecVersion CheckTxVersion CheckGenesis CheckMortality "+
+		"CheckNonce CheckWeight ChargeTransactionPayment ChargeAssetTxPayment] differ from the default ones, verifying "+
+		"requires metadata v14")
+}
+
+func TestExtrinsic_VerifySignature_Unsigned(t *testing.T) {
+	c, err := NewCall(ExamplaryMetadataV4, "balances.transfer",
+		NewAddressFromAccountID(MustHexDecodeString("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")),
+		NewUCompactFromUInt(6969))
+	assert.NoError(t, err)
+
+	_, err = NewExtrinsic(c).VerifySignature(Hash{}, Hash{}, RuntimeVersion{})
+	assert.EqualError(t, err, "extrinsic is not signed")
+}
+
 func ExampleExtrinsic() {
 	bob, err := NewAddressFromHexAccountID("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
 	if err != nil {
@@ -223,3 +674,65 @@ func TestNewCallV8(t *testing.T) {
 
 	assert.Equal(t, "0x010003", enc)
 }
+
+func TestNewCallFromArgs(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	bob, err := NewMultiAddressFromHexAccountID("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+	assert.NoError(t, err)
+	expected := Call{
+		CallIndex: CallIndex{SectionIndex: 5, MethodIndex: 0},
+		Args: MustHexDecodeString("0x008eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48" +
+			"e5c0"),
+	}
+
+	c, err := NewCallFromArgs(&meta, "Balances.transfer", map[string]interface{}{
+		"dest":  bob,
+		"value": uint64(12345),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, c)
+
+	c, err = NewCallFromArgs(&meta, "Balances.transfer", map[string]interface{}{
+		"dest":  map[string]interface{}{"Id": bob.AsID},
+		"value": "12345",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, c)
+
+	batch, err := NewCallFromArgs(&meta, "Utility.batch", map[string]interface{}{
+		"calls": []Call{c, c},
+	})
+	assert.NoError(t, err)
+	enc, err := EncodeToBytes(c)
+	assert.NoError(t, err)
+	assert.Equal(t, append(append([]byte{0x08}, enc...), enc...), []byte(batch.Args))
+}
+
+func TestNewCallFromArgs_Errors(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	_, err = NewCallFromArgs(&meta, "Balances.transfer", map[string]interface{}{"value": 1})
+	assert.EqualError(t, err, "missing argument dest (<T::Lookup as StaticLookup>::Source) for call Balances.transfer")
+
+	_, err = NewCallFromArgs(&meta, "Balances.transfer", map[string]interface{}{
+		"dest":  map[string]interface{}{"Id": AccountID{}},
+		"value": -1,
+	})
+	assert.EqualError(t, err, "invalid argument value (T::Balance) for call Balances.transfer: "+
+		"type 51 is a compact, but -1 is negative")
+
+	_, err = NewCallFromArgs(&meta, "Balances.transfer", map[string]interface{}{
+		"dest":  map[string]interface{}{"Id": AccountID{}},
+		"value": 1,
+		"tip":   1,
+	})
+	assert.EqualError(t, err, "unknown argument tip for call Balances.transfer")
+
+	_, err = NewCallFromArgs(&meta, "Balances.transferAll", map[string]interface{}{})
+	assert.EqualError(t, err, "call transferAll not found within module Balances")
+}
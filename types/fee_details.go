@@ -0,0 +1,113 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+)
+
+// FeeDetails is the breakdown of the fee of an extrinsic as returned by payment_queryFeeDetails
+type FeeDetails struct {
+	// HasInclusionFee is false for unsigned extrinsics, which don't pay an inclusion fee
+	HasInclusionFee bool
+	InclusionFee    InclusionFee
+}
+
+// InclusionFee is the fee paid for the inclusion of an extrinsic in a block
+type InclusionFee struct {
+	// BaseFee is the minimum amount paid for any extrinsic
+	BaseFee U128
+	// LenFee is the fee depending on the encoded length of the extrinsic
+	LenFee U128
+	// AdjustedWeightFee is the fee depending on the weight of the extrinsic, adjusted by the fee multiplier
+	AdjustedWeightFee U128
+}
+
+// Total returns the sum of all parts of the inclusion fee
+func (f InclusionFee) Total() U128 {
+	total := new(big.Int)
+	for _, part := range []U128{f.BaseFee, f.LenFee, f.AdjustedWeightFee} {
+		if part.Int != nil {
+			total.Add(total, part.Int)
+		}
+	}
+	return NewU128(*total)
+}
+
+type feeDetailsJSON struct {
+	InclusionFee *inclusionFeeJSON `json:"inclusionFee"`
+}
+
+type inclusionFeeJSON struct {
+	BaseFee           json.RawMessage `json:"baseFee"`
+	LenFee            json.RawMessage `json:"lenFee"`
+	AdjustedWeightFee json.RawMessage `json:"adjustedWeightFee"`
+}
+
+// UnmarshalJSON fills FeeDetails with the JSON encoded byte array given by bz
+func (f *FeeDetails) UnmarshalJSON(bz []byte) error {
+	var tmp feeDetailsJSON
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+
+	*f = FeeDetails{}
+	if tmp.InclusionFee == nil {
+		return nil
+	}
+	f.HasInclusionFee = true
+
+	fees := []struct {
+		name   string
+		raw    json.RawMessage
+		target *U128
+	}{
+		{"baseFee", tmp.InclusionFee.BaseFee, &f.InclusionFee.BaseFee},
+		{"lenFee", tmp.InclusionFee.LenFee, &f.InclusionFee.LenFee},
+		{"adjustedWeightFee", tmp.InclusionFee.AdjustedWeightFee, &f.InclusionFee.AdjustedWeightFee},
+	}
+	for _, fee := range fees {
+		i, err := unmarshalNumberOrHex(fee.raw)
+		if err != nil {
+			return fmt.Errorf("unable to decode %v: %v", fee.name, err)
+		}
+		*fee.target = NewU128(*i)
+	}
+	return nil
+}
+
+// MarshalJSON returns a JSON encoded byte array of FeeDetails, encoding the fees as hex strings like the node does
+func (f FeeDetails) MarshalJSON() ([]byte, error) {
+	if !f.HasInclusionFee {
+		return json.Marshal(feeDetailsJSON{})
+	}
+
+	hexFee := func(u U128) json.RawMessage {
+		i := u.Int
+		if i == nil {
+			i = new(big.Int)
+		}
+		return json.RawMessage(fmt.Sprintf(`"%#x"`, i))
+	}
+	return json.Marshal(feeDetailsJSON{InclusionFee: &inclusionFeeJSON{
+		BaseFee:           hexFee(f.InclusionFee.BaseFee),
+		LenFee:            hexFee(f.InclusionFee.LenFee),
+		AdjustedWeightFee: hexFee(f.InclusionFee.AdjustedWeightFee),
+	}})
+}
@@ -0,0 +1,64 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	. "github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeDetails_UnmarshalJSON(t *testing.T) {
+	var details FeeDetails
+	err := json.Unmarshal([]byte(`{"inclusionFee":{"baseFee":"0x3b9aca00","lenFee":"0x1dcd6500",
+		"adjustedWeightFee":12}}`), &details)
+	assert.NoError(t, err)
+	assert.Equal(t, FeeDetails{HasInclusionFee: true, InclusionFee: InclusionFee{
+		BaseFee:           NewU128(*big.NewInt(1000000000)),
+		LenFee:            NewU128(*big.NewInt(500000000)),
+		AdjustedWeightFee: NewU128(*big.NewInt(12)),
+	}}, details)
+	assert.Equal(t, NewU128(*big.NewInt(1500000012)), details.InclusionFee.Total())
+
+	err = json.Unmarshal([]byte(`{"inclusionFee":null}`), &details)
+	assert.NoError(t, err)
+	assert.Equal(t, FeeDetails{}, details)
+
+	err = json.Unmarshal([]byte(`{"inclusionFee":{"baseFee":"0xzz","lenFee":"0x0","adjustedWeightFee":"0x0"}}`),
+		&details)
+	assert.EqualError(t, err, `unable to decode baseFee: invalid number "0xzz"`)
+}
+
+func TestFeeDetails_JSONRoundtrip(t *testing.T) {
+	details := FeeDetails{HasInclusionFee: true, InclusionFee: InclusionFee{
+		BaseFee:           NewU128(*big.NewInt(1000000000)),
+		LenFee:            NewU128(*big.NewInt(500000000)),
+		AdjustedWeightFee: NewU128(*big.NewInt(12)),
+	}}
+	bz, err := json.Marshal(details)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"inclusionFee":{"baseFee":"0x3b9aca00","lenFee":"0x1dcd6500","adjustedWeightFee":"0xc"}}`,
+		string(bz))
+
+	var dec FeeDetails
+	err = json.Unmarshal(bz, &dec)
+	assert.NoError(t, err)
+	assert.Equal(t, details, dec)
+}
@@ -0,0 +1,209 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/scale"
+)
+
+// GrandpaJustification is a GRANDPA justification for the finality of a block. It proves that more than 2/3 of the
+// authorities of the set precommitted to the target of the commit, or one of its descendants, in the given round.
+type GrandpaJustification struct {
+	Round  U64
+	Commit GrandpaCommit
+	// VotesAncestries are the headers of the blocks between the commit target and the precommit targets, needed to
+	// show that the precommits vote for descendants of the commit target
+	VotesAncestries []Header
+}
+
+// UnmarshalJSON fills GrandpaJustification with the JSON encoded byte array given by bz, which is the hex encoded
+// justification as sent by grandpa_subscribeJustifications
+func (j *GrandpaJustification) UnmarshalJSON(bz []byte) error {
+	var tmp string
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+	return DecodeFromHexString(tmp, j)
+}
+
+// MarshalJSON returns a JSON encoded byte array of GrandpaJustification
+func (j GrandpaJustification) MarshalJSON() ([]byte, error) {
+	s, err := EncodeToHexString(j)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// GrandpaCommit is the commit message of a GRANDPA round, the target block and the signed precommits for it
+type GrandpaCommit struct {
+	TargetHash   Hash
+	TargetNumber U32
+	Precommits   []GrandpaSignedPrecommit
+}
+
+// GrandpaPrecommit is a precommit for a block and its ancestors
+type GrandpaPrecommit struct {
+	TargetHash   Hash
+	TargetNumber U32
+}
+
+// GrandpaSignedPrecommit is a precommit with the ed25519 signature of the authority that sent it
+type GrandpaSignedPrecommit struct {
+	Precommit GrandpaPrecommit
+	Signature Signature
+	ID        AuthorityID
+}
+
+// FinalityProof is a proof of the finality of a block as returned by grandpa_proveFinality
+type FinalityProof struct {
+	// Block is the hash of the block finalized by Justification
+	Block Hash
+	// Justification is the encoded GrandpaJustification, use DecodeJustification to decode it
+	Justification Bytes
+	// UnknownHeaders are the headers from the requested block up to the finalized block, if they differ
+	UnknownHeaders []Header
+}
+
+// DecodeJustification decodes the GRANDPA justification of the proof
+func (p FinalityProof) DecodeJustification() (GrandpaJustification, error) {
+	var j GrandpaJustification
+	err := DecodeFromBytes(p.Justification, &j)
+	return j, err
+}
+
+// ReportedRoundStates is the state of the current GRANDPA rounds as returned by grandpa_roundState
+type ReportedRoundStates struct {
+	SetID      U32          `json:"setId"`
+	Best       RoundState   `json:"best"`
+	Background []RoundState `json:"background"`
+}
+
+// RoundState is the state of the votes of a GRANDPA round
+type RoundState struct {
+	Round           U32   `json:"round"`
+	TotalWeight     U32   `json:"totalWeight"`
+	ThresholdWeight U32   `json:"thresholdWeight"`
+	Prevotes        Votes `json:"prevotes"`
+	Precommits      Votes `json:"precommits"`
+}
+
+// Votes is the weight of the votes received in a round and the SS58 addresses of the authorities that did not vote
+type Votes struct {
+	CurrentWeight U32      `json:"currentWeight"`
+	Missing       []string `json:"missing"`
+}
+
+// GrandpaEngineID is the ConsensusEngineID of GRANDPA, b"FRNK", used in the consensus digest items of its logs
+const GrandpaEngineID ConsensusEngineID = 0x4b4e5246
+
+// GrandpaAuthority is a GRANDPA authority with its voting weight, as stored in Grandpa.Authorities
+type GrandpaAuthority struct {
+	ID     AuthorityID
+	Weight U64
+}
+
+// GrandpaScheduledChange is a change of the GRANDPA authority set that is enacted once the block scheduling it has
+// been finalized and Delay more blocks have been imported
+type GrandpaScheduledChange struct {
+	NextAuthorities []GrandpaAuthority
+	Delay           U32
+}
+
+// GrandpaForcedChange is a change of the GRANDPA authority set that is enacted Delay blocks after the block scheduling
+// it was imported, without it being finalized
+type GrandpaForcedChange struct {
+	// MedianLastFinalized is the block number of the median last finalized block at the time of the change
+	MedianLastFinalized U32
+	Change              GrandpaScheduledChange
+}
+
+// GrandpaConsensusLog is a GRANDPA log found in the consensus digest items of a header, see GrandpaEngineID
+type GrandpaConsensusLog struct {
+	IsScheduledChange bool
+	AsScheduledChange GrandpaScheduledChange
+	IsForcedChange    bool
+	AsForcedChange    GrandpaForcedChange
+	// An authority of the current set, given by its index, has been disabled
+	IsOnDisabled bool
+	AsOnDisabled U64
+	// The current authority set is paused after the given delay
+	IsPause bool
+	AsPause U32
+	// The current authority set is resumed after the given delay
+	IsResume bool
+	AsResume U32
+}
+
+func (l *GrandpaConsensusLog) Decode(decoder scale.Decoder) error {
+	b, err := decoder.ReadOneByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case 1:
+		l.IsScheduledChange = true
+		err = decoder.Decode(&l.AsScheduledChange)
+	case 2:
+		l.IsForcedChange = true
+		err = decoder.Decode(&l.AsForcedChange)
+	case 3:
+		l.IsOnDisabled = true
+		err = decoder.Decode(&l.AsOnDisabled)
+	case 4:
+		l.IsPause = true
+		err = decoder.Decode(&l.AsPause)
+	case 5:
+		l.IsResume = true
+		err = decoder.Decode(&l.AsResume)
+	default:
+		return fmt.Errorf("unknown GrandpaConsensusLog enum: %v", b)
+	}
+	return err
+}
+
+func (l GrandpaConsensusLog) Encode(encoder scale.Encoder) error {
+	var err1, err2 error
+	switch {
+	case l.IsScheduledChange:
+		err1 = encoder.PushByte(1)
+		err2 = encoder.Encode(l.AsScheduledChange)
+	case l.IsForcedChange:
+		err1 = encoder.PushByte(2)
+		err2 = encoder.Encode(l.AsForcedChange)
+	case l.IsOnDisabled:
+		err1 = encoder.PushByte(3)
+		err2 = encoder.Encode(l.AsOnDisabled)
+	case l.IsPause:
+		err1 = encoder.PushByte(4)
+		err2 = encoder.Encode(l.AsPause)
+	case l.IsResume:
+		err1 = encoder.PushByte(5)
+		err2 = encoder.Encode(l.AsResume)
+	default:
+		return fmt.Errorf("empty GrandpaConsensusLog")
+	}
+
+	if err1 != nil {
+		return err1
+	}
+	return err2
+}
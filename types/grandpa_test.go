@@ -0,0 +1,117 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var exampleGrandpaJustification = GrandpaJustification{
+	Round: 1234,
+	Commit: GrandpaCommit{
+		TargetHash:   Hash{1, 2, 3},
+		TargetNumber: 42,
+		Precommits: []GrandpaSignedPrecommit{
+			{
+				Precommit: GrandpaPrecommit{TargetHash: Hash{1, 2, 3}, TargetNumber: 42},
+				Signature: Signature{4, 5, 6},
+				ID:        AuthorityID{7, 8, 9},
+			},
+			{
+				Precommit: GrandpaPrecommit{TargetHash: Hash{2, 3, 4}, TargetNumber: 43},
+				Signature: Signature{5, 6, 7},
+				ID:        AuthorityID{8, 9, 10},
+			},
+		},
+	},
+	VotesAncestries: []Header{exampleHeader},
+}
+
+func TestGrandpaJustification_EncodeDecode(t *testing.T) {
+	assertRoundtrip(t, exampleGrandpaJustification)
+}
+
+func TestGrandpaJustification_EncodedLength(t *testing.T) {
+	// round, target, two signed precommits of 36 + 64 + 32 bytes and a header with their compact lengths
+	assertEncodedLength(t, []encodedLengthAssert{{exampleGrandpaJustification, 8 + 36 + 1 + 2*132 + 1 + 162}})
+}
+
+func TestGrandpaJustification_JSON(t *testing.T) {
+	bz, err := json.Marshal(exampleGrandpaJustification)
+	assert.NoError(t, err)
+
+	enc, err := EncodeToHexString(exampleGrandpaJustification)
+	assert.NoError(t, err)
+	assert.Equal(t, `"`+enc+`"`, string(bz))
+
+	var dec GrandpaJustification
+	err = json.Unmarshal(bz, &dec)
+	assert.NoError(t, err)
+	assert.Equal(t, exampleGrandpaJustification, dec)
+}
+
+func TestFinalityProof_DecodeJustification(t *testing.T) {
+	justification, err := EncodeToBytes(exampleGrandpaJustification)
+	assert.NoError(t, err)
+	proof := FinalityProof{Block: Hash{1, 2, 3}, Justification: justification, UnknownHeaders: []Header{exampleHeader}}
+	assertRoundtrip(t, proof)
+
+	dec, err := proof.DecodeJustification()
+	assert.NoError(t, err)
+	assert.Equal(t, exampleGrandpaJustification, dec)
+}
+
+func TestReportedRoundStates_UnmarshalJSON(t *testing.T) {
+	var states ReportedRoundStates
+	err := json.Unmarshal([]byte(`{"setId":3,"best":{"round":1090,"totalWeight":4,"thresholdWeight":3,
+		"prevotes":{"currentWeight":3,"missing":["5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu"]},
+		"precommits":{"currentWeight":0,"missing":[]}},"background":[]}`), &states)
+	assert.NoError(t, err)
+	assert.Equal(t, ReportedRoundStates{
+		SetID: 3,
+		Best: RoundState{
+			Round:           1090,
+			TotalWeight:     4,
+			ThresholdWeight: 3,
+			Prevotes: Votes{CurrentWeight: 3, Missing: []string{
+				"5FA9nQDVg267DEd8m1ZypXLBnvN7SFxYwV7ndqSYGiN9TTpu"}},
+			Precommits: Votes{CurrentWeight: 0, Missing: []string{}},
+		},
+		Background: []RoundState{},
+	}, states)
+}
+
+func TestGrandpaConsensusLog_EncodeDecode(t *testing.T) {
+	change := GrandpaScheduledChange{
+		NextAuthorities: []GrandpaAuthority{{ID: AuthorityID{1, 2}, Weight: 1}, {ID: AuthorityID{3, 4}, Weight: 2}},
+		Delay:           5,
+	}
+	assertRoundtrip(t, GrandpaConsensusLog{IsScheduledChange: true, AsScheduledChange: change})
+	assertRoundtrip(t, GrandpaConsensusLog{IsForcedChange: true, AsForcedChange: GrandpaForcedChange{
+		MedianLastFinalized: 100, Change: change}})
+	assertRoundtrip(t, GrandpaConsensusLog{IsOnDisabled: true, AsOnDisabled: 3})
+	assertRoundtrip(t, GrandpaConsensusLog{IsPause: true, AsPause: 10})
+	assertRoundtrip(t, GrandpaConsensusLog{IsResume: true, AsResume: 10})
+
+	var log GrandpaConsensusLog
+	err := DecodeFromBytes([]byte{6}, &log)
+	assert.EqualError(t, err, "unknown GrandpaConsensusLog enum: 6")
+}
@@ -17,6 +17,7 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/JFJun/go-substrate-rpc-client/v3/scale"
@@ -286,6 +287,107 @@ func (m *Metadata) FindStorageEntryMetadata(module string, fn string) (StorageEn
 	}
 }
 
+func (m *Metadata) FindConstantValue(module string, constant string) ([]byte, error) {
+	switch {
+	case m.IsMetadataV4:
+		return m.AsMetadataV4.FindConstantValue(module, constant)
+	case m.IsMetadataV7:
+		return m.AsMetadataV7.FindConstantValue(module, constant)
+	case m.IsMetadataV8:
+		return m.AsMetadataV8.FindConstantValue(module, constant)
+	case m.IsMetadataV9:
+		return m.AsMetadataV9.FindConstantValue(module, constant)
+	case m.IsMetadataV10:
+		return m.AsMetadataV10.FindConstantValue(module, constant)
+	case m.IsMetadataV11:
+		return m.AsMetadataV11.FindConstantValue(module, constant)
+	case m.IsMetadataV12:
+		return m.AsMetadataV12.FindConstantValue(module, constant)
+	case m.IsMetadataV13:
+		return m.AsMetadataV13.FindConstantValue(module, constant)
+	case m.IsMetadataV14:
+		return m.AsMetadataV14.FindConstantValue(module, constant)
+	default:
+		return nil, fmt.Errorf("unsupported metadata version")
+	}
+}
+
+// GetConstant decodes the value of the constant of the given pallet into target, which works for all metadata
+// versions with constants
+func (m *Metadata) GetConstant(pallet, name string, target interface{}) error {
+	value, err := m.FindConstantValue(pallet, name)
+	if err != nil {
+		return err
+	}
+	return DecodeFromBytes(value, target)
+}
+
+// GetConstantValue decodes the constant of the given pallet into a Value described by its type in the V14 type
+// registry, which is useful for composite constants without a matching Go type such as System.BlockWeights
+func (m *Metadata) GetConstantValue(pallet, name string) (Value, error) {
+	if !m.IsMetadataV14 {
+		return Value{}, fmt.Errorf("decoding constants into values requires metadata v14, got v%v", m.Version)
+	}
+
+	c, err := m.AsMetadataV14.findConstant(pallet, name)
+	if err != nil {
+		return Value{}, err
+	}
+	return DecodeWithType(&m.AsMetadataV14, c.Type.Int64(), c.Value)
+}
+
+// ModuleErrorMetadata describes an error of a pallet as found in the metadata
+type ModuleErrorMetadata struct {
+	Pallet Text
+	Name   Text
+	Docs   []Text
+}
+
+// String returns the error as Pallet.Name, e.g. Balances.InsufficientBalance
+func (e ModuleErrorMetadata) String() string {
+	return fmt.Sprintf("%v.%v", e.Pallet, e.Name)
+}
+
+// FindError returns the pallet name, error name and docs of the error with the given module and error index, as found
+// in a DispatchError of a module. It requires metadata v14.
+func (m *Metadata) FindError(moduleIndex, errorIndex uint8) (ModuleErrorMetadata, error) {
+	if !m.IsMetadataV14 {
+		return ModuleErrorMetadata{}, fmt.Errorf("finding errors requires metadata v14, got v%v", m.Version)
+	}
+
+	mod, variant, err := m.AsMetadataV14.FindError(moduleIndex, errorIndex)
+	if err != nil {
+		return ModuleErrorMetadata{}, err
+	}
+	return ModuleErrorMetadata{Pallet: mod.Name, Name: variant.Name, Docs: variant.Docs}, nil
+}
+
+// FindModuleErrorLength returns the number of bytes the error of a module is encoded with in the DispatchError of the
+// runtime, 1 for older runtimes and 4 for newer ones. It requires metadata v14.
+func (m *Metadata) FindModuleErrorLength() (int, error) {
+	if !m.IsMetadataV14 {
+		return 0, fmt.Errorf("finding the module error length requires metadata v14, got v%v", m.Version)
+	}
+	return m.AsMetadataV14.FindModuleErrorLength()
+}
+
+// moduleErrorLength returns the module error length of the runtime, which is 1 for runtimes before metadata v14 and
+// for those not declaring a DispatchError
+func (m *Metadata) moduleErrorLength() int {
+	if !m.IsMetadataV14 {
+		return 1
+	}
+	return m.AsMetadataV14.moduleErrorLength()
+}
+
+// DecodeDispatchError decodes an encoded DispatchError of the runtime, with module errors being encoded with the
+// length declared by the metadata
+func (m *Metadata) DecodeDispatchError(bz []byte) (DispatchError, error) {
+	var d DispatchError
+	err := d.decodeWithModuleErrorLength(*scale.NewDecoder(bytes.NewReader(bz)), m.moduleErrorLength())
+	return d, err
+}
+
 func (m *Metadata) ExistsModuleMetadata(module string) bool {
 	switch {
 	case m.IsMetadataV4:
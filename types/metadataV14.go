@@ -16,6 +16,8 @@ type MetadataV14 struct {
 	LookUpData map[int64]*Si1Type
 	Pallets    []PalletMetadataV14
 	Extrinsic  ExtrinsicMetadataV14
+	// moduleErrLen caches moduleErrorLength, 0 if not computed yet
+	moduleErrLen int
 }
 
 func (d *MetadataV14) FindCallIndex(call string) (CallIndex, error) {
@@ -44,6 +46,46 @@ func (d *MetadataV14) FindCallIndex(call string) (CallIndex, error) {
 	return CallIndex{}, fmt.Errorf("module %v not found in metadata for call %v", s[0], call)
 }
 
+// findCallVariant returns the pallet and the variant of its call enum for a call in the form "Pallet.call"
+func (d *MetadataV14) findCallVariant(call string) (*PalletMetadataV14, *Si1Variant, error) {
+	s := strings.Split(call, ".")
+	if len(s) != 2 {
+		return nil, nil, fmt.Errorf("call %v is not in the form Pallet.call", call)
+	}
+	for i := range d.Pallets {
+		mod := &d.Pallets[i]
+		if !mod.HasCalls || string(mod.Name) != s[0] {
+			continue
+		}
+		typ, err := d.FindType(mod.Calls.Type.Int64())
+		if err != nil {
+			return nil, nil, err
+		}
+		for j := range typ.Def.Variant.Variants {
+			if string(typ.Def.Variant.Variants[j].Name) == s[1] {
+				return mod, &typ.Def.Variant.Variants[j], nil
+			}
+		}
+		return nil, nil, fmt.Errorf("call %v not found within module %v", s[1], s[0])
+	}
+	return nil, nil, fmt.Errorf("module %v not found in metadata for call %v", s[0], call)
+}
+
+// FindExtrinsicParamType returns the type id of a type parameter of the extrinsic type, such as "Address", "Call",
+// "Signature" or "Extra"
+func (d *MetadataV14) FindExtrinsicParamType(name string) (int64, error) {
+	typ, err := d.FindType(d.Extrinsic.Type.Int64())
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range typ.Params {
+		if string(p.Name) == name {
+			return p.Type.Int64(), nil
+		}
+	}
+	return 0, fmt.Errorf("extrinsic type has no parameter %v", name)
+}
+
 func (d *MetadataV14) FindEventNamesForEventID(eventID EventID) (Text, Text, error) {
 	for _, mod := range d.Pallets {
 		if !mod.HasEvents {
@@ -88,6 +130,116 @@ func (d *MetadataV14) FindStorageEntryMetadata(module string, fn string) (Storag
 	return nil, fmt.Errorf("module %v not found in metadata", module)
 }
 
+func (d *MetadataV14) FindConstantValue(module string, constant string) ([]byte, error) {
+	c, err := d.findConstant(module, constant)
+	if err != nil {
+		return nil, err
+	}
+	return c.Value, nil
+}
+
+func (d *MetadataV14) findConstant(module string, constant string) (*PalletConstantMetadataV14, error) {
+	for _, mod := range d.Pallets {
+		if string(mod.Name) != module {
+			continue
+		}
+		for i := range mod.Constants {
+			if string(mod.Constants[i].Name) == constant {
+				return &mod.Constants[i], nil
+			}
+		}
+		return nil, fmt.Errorf("constant %v not found within module %v", constant, module)
+	}
+	return nil, fmt.Errorf("module %v not found in metadata", module)
+}
+
+// FindError returns the pallet and the variant of its error enum for the given module and error index, as found in
+// a DispatchError of a module
+func (d *MetadataV14) FindError(moduleIndex, errorIndex uint8) (*PalletMetadataV14, *Si1Variant, error) {
+	for i := range d.Pallets {
+		mod := &d.Pallets[i]
+		if uint8(mod.Index) != moduleIndex {
+			continue
+		}
+		if !mod.HasErrors {
+			return nil, nil, fmt.Errorf("module %v has no errors", mod.Name)
+		}
+		typ, err := d.FindType(mod.Errors.Type.Int64())
+		if err != nil {
+			return nil, nil, err
+		}
+		if !typ.Def.IsVariant {
+			return nil, nil, fmt.Errorf("error type of module %v is not a variant", mod.Name)
+		}
+		variant, err := findVariant(typ.Def.Variant, errorIndex)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error of module %v: %v", mod.Name, err)
+		}
+		return mod, variant, nil
+	}
+	return nil, nil, fmt.Errorf("module index %v out of range", moduleIndex)
+}
+
+// FindModuleErrorLength returns the number of bytes the error of a module is encoded with in the DispatchError of the
+// runtime, 1 for older runtimes and 4 for newer ones
+func (d *MetadataV14) FindModuleErrorLength() (int, error) {
+	for _, lookUp := range d.Lookup {
+		path := lookUp.Type.Path
+		if len(path) != 2 || path[0] != "sp_runtime" || path[1] != "DispatchError" || !lookUp.Type.Def.IsVariant {
+			continue
+		}
+		for _, v := range lookUp.Type.Def.Variant.Variants {
+			if v.Name != "Module" {
+				continue
+			}
+			fields := v.Fields
+			if len(fields) == 1 {
+				// newer runtimes wrap index and error in a ModuleError struct
+				typ, err := d.FindType(fields[0].Type.Int64())
+				if err != nil {
+					return 0, err
+				}
+				fields = typ.Def.Composite.Fields
+			}
+			for _, f := range fields {
+				if f.Name != "error" {
+					continue
+				}
+				typ, err := d.FindType(f.Type.Int64())
+				if err != nil {
+					return 0, err
+				}
+				if typ.Def.IsArray {
+					return int(typ.Def.Array.Len), nil
+				}
+				return 1, nil
+			}
+		}
+		return 0, fmt.Errorf("DispatchError has no module error")
+	}
+	return 0, fmt.Errorf("DispatchError not found in metadata")
+}
+
+// moduleErrorLength returns the module error length of the runtime like FindModuleErrorLength, falling back to the
+// single byte of older runtimes if the metadata declares no DispatchError. The length is computed once and cached.
+func (d *MetadataV14) moduleErrorLength() int {
+	d.ldLk.Lock()
+	n := d.moduleErrLen
+	d.ldLk.Unlock()
+	if n != 0 {
+		return n
+	}
+
+	n, err := d.FindModuleErrorLength()
+	if err != nil {
+		n = 1
+	}
+	d.ldLk.Lock()
+	d.moduleErrLen = n
+	d.ldLk.Unlock()
+	return n
+}
+
 func (d *MetadataV14) ExistsModuleMetadata(module string) bool {
 	for _, mod := range d.Pallets {
 		if string(mod.Name) == module {
@@ -106,9 +258,10 @@ func (d *MetadataV14) Decode(decoder scale.Decoder) error {
 	// 处理lookUp
 	d.LookUpData = make(map[int64]*Si1Type)
 	d.ldLk.Lock()
-	for _, lookUp := range d.Lookup {
-		d.LookUpData[lookUp.Id.Int64()] = &lookUp.Type
+	for i := range d.Lookup {
+		d.LookUpData[d.Lookup[i].Id.Int64()] = &d.Lookup[i].Type
 	}
+	d.moduleErrLen = 0
 	d.ldLk.Unlock()
 
 	err = decoder.Decode(&d.Pallets)
@@ -452,10 +605,8 @@ func (d *MetadataV14) findNameByCallIndex(callIdx string) (string, string, error
 			continue
 		}
 		if uint8(mod.Index) == data[0] {
-			callType := mod.Calls.Type.Int64()
-			d.ldLk.Lock()
-			call := d.LookUpData[callType]
-			if call == nil {
+			call, err := d.FindType(mod.Calls.Type.Int64())
+			if err != nil {
 				return "", "", fmt.Errorf("%s do not have this call id: %d", mod.Name, data[1])
 			}
 			if len(call.Def.Variant.Variants) == 0 {
@@ -466,7 +617,6 @@ func (d *MetadataV14) findNameByCallIndex(callIdx string) (string, string, error
 					return string(mod.Name), string(vars.Name), nil
 				}
 			}
-			d.ldLk.Unlock()
 		}
 	}
 	return "", "", errors.New("do not find")
@@ -477,14 +627,11 @@ func (d *MetadataV14) getConstants(modName, constantsName string) (constantsType
 		if string(mod.Name) == modName {
 			for _, constants := range mod.Constants {
 				if string(constants.Name) == constantsName {
-					constantsTypeId := constants.Type.Int64()
-					d.ldLk.Lock()
-					siType := d.LookUpData[constantsTypeId]
-					if siType == nil {
+					siType, err := d.FindType(constants.Type.Int64())
+					if err != nil {
 						return "", nil, fmt.Errorf("%s.%s constants type is nil ptr", mod.Name, constants.Name)
 					}
 					constantsType = siType.Def.Primitive.Value
-					d.ldLk.Unlock()
 					constantsValue = constants.Value
 					return constantsType, constantsValue, nil
 				}
@@ -106,6 +106,10 @@ func (m *MetadataV4) FindStorageEntryMetadata(module string, fn string) (Storage
 	return nil, fmt.Errorf("module %v not found in metadata", module)
 }
 
+func (m *MetadataV4) FindConstantValue(module string, constant string) ([]byte, error) {
+	return nil, fmt.Errorf("metadata v4 does not contain constants")
+}
+
 func (m *MetadataV4) ExistsModuleMetadata(module string) bool {
 	for _, mod := range m.Modules {
 		if string(mod.Prefix) == module {
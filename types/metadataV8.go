@@ -102,6 +102,21 @@ func (m *MetadataV8) FindStorageEntryMetadata(module string, fn string) (Storage
 	return nil, fmt.Errorf("module %v not found in metadata", module)
 }
 
+func (m *MetadataV8) FindConstantValue(module string, constant string) ([]byte, error) {
+	for _, mod := range m.Modules {
+		if string(mod.Name) != module {
+			continue
+		}
+		for _, c := range mod.Constants {
+			if string(c.Name) == constant {
+				return c.Value, nil
+			}
+		}
+		return nil, fmt.Errorf("constant %v not found within module %v", constant, module)
+	}
+	return nil, fmt.Errorf("module %v not found in metadata", module)
+}
+
 func (m *MetadataV8) ExistsModuleMetadata(module string) bool {
 	for _, mod := range m.Modules {
 		if string(mod.Name) == module {
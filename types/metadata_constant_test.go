@@ -0,0 +1,93 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"math/big"
+	"testing"
+
+	. "github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata_GetConstant(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	var deposit U128
+	err = meta.GetConstant("Balances", "ExistentialDeposit", &deposit)
+	assert.NoError(t, err)
+	assert.Equal(t, NewU128(*big.NewInt(10000000000)), deposit)
+
+	var count U32
+	err = meta.GetConstant("System", "BlockHashCount", &count)
+	assert.NoError(t, err)
+	assert.Equal(t, U32(2400), count)
+
+	err = meta.GetConstant("System", "Unknown", &count)
+	assert.EqualError(t, err, "constant Unknown not found within module System")
+
+	err = meta.GetConstant("Unknown", "BlockHashCount", &count)
+	assert.EqualError(t, err, "module Unknown not found in metadata")
+}
+
+func TestMetadata_GetConstant_V10(t *testing.T) {
+	var deposit U128
+	err := ExamplaryMetadataV10.GetConstant("Balances", "ExistentialDeposit", &deposit)
+	assert.NoError(t, err)
+	assert.Equal(t, NewU128(*big.NewInt(100000000000000)), deposit)
+
+	var period U64
+	err = ExamplaryMetadataV10.GetConstant("Timestamp", "MinimumPeriod", &period)
+	assert.NoError(t, err)
+	assert.Equal(t, U64(1500), period)
+}
+
+func TestMetadata_GetConstant_V4(t *testing.T) {
+	var deposit U128
+	err := ExamplaryMetadataV4.GetConstant("Balances", "ExistentialDeposit", &deposit)
+	assert.EqualError(t, err, "metadata v4 does not contain constants")
+}
+
+func TestMetadata_GetConstantValue(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	weights, err := meta.GetConstantValue("System", "BlockWeights")
+	assert.NoError(t, err)
+	assert.Equal(t, ValueKindComposite, weights.Kind)
+
+	maxBlock, ok := weights.Field("max_block")
+	assert.True(t, ok)
+	assert.Equal(t, ValueKindPrimitive, maxBlock.Kind)
+	_, ok = weights.Field("per_class")
+	assert.True(t, ok)
+
+	length, err := meta.GetConstantValue("System", "BlockLength")
+	assert.NoError(t, err)
+	max, ok := length.Field("max")
+	assert.True(t, ok)
+	normal, ok := max.Field("normal")
+	assert.True(t, ok)
+	// 75% of the 5 MiB block length are available for normal extrinsics
+	assert.Equal(t, U32(5*1024*1024*3/4), normal.Primitive)
+
+	_, err = ExamplaryMetadataV10.GetConstantValue("Balances", "ExistentialDeposit")
+	assert.EqualError(t, err, "decoding constants into values requires metadata v14, got v10")
+}
@@ -0,0 +1,175 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+)
+
+// MmrLeafVersion is the version of the MMR leaf format, with the major version in the upper three bits and the minor
+// version in the lower five bits
+type MmrLeafVersion U8
+
+// ParentNumberAndHash is the number and hash of the parent of the block an MMR leaf was appended at
+type ParentNumberAndHash struct {
+	ParentNumber U32
+	Hash         Hash
+}
+
+// BeefyNextAuthoritySet is the commitment to the next BEEFY validator set stored in every MMR leaf, with Root being the
+// merkle root of the Ethereum addresses of the validators
+type BeefyNextAuthoritySet struct {
+	ID   U64
+	Len  U32
+	Root H256
+}
+
+// MmrLeaf is a leaf of the Merkle Mountain Range maintained by the BEEFY MMR pallet, appended for every block
+type MmrLeaf struct {
+	Version               MmrLeafVersion
+	ParentNumberAndHash   ParentNumberAndHash
+	BeefyNextAuthoritySet BeefyNextAuthoritySet
+	// LeafExtra is the merkle root of the parachain heads included in the block
+	LeafExtra H256
+}
+
+// MmrProof is a proof of a single leaf of an MMR with LeafCount leaves
+type MmrProof struct {
+	LeafIndex U64
+	LeafCount U64
+	Items     []H256
+}
+
+// MmrBatchProof is a proof of several leaves of an MMR with LeafCount leaves
+type MmrBatchProof struct {
+	LeafIndices []U64
+	LeafCount   U64
+	Items       []H256
+}
+
+// MmrLeafProof is a leaf with its proof as returned by mmr_generateProof
+type MmrLeafProof struct {
+	// BlockHash is the hash of the block at which the proof was generated, its MMR root is the one to verify against
+	BlockHash Hash
+	Leaf      MmrLeaf
+	Proof     MmrProof
+}
+
+// mmrLeafProofJSON is the JSON representation of the proofs sent by the MMR RPC, with leaves and proofs being SCALE
+// encoded and hex encoded
+type mmrLeafProofJSON struct {
+	BlockHash Hash   `json:"blockHash"`
+	Leaf      string `json:"leaf,omitempty"`
+	Leaves    string `json:"leaves,omitempty"`
+	Proof     string `json:"proof"`
+}
+
+// UnmarshalJSON fills MmrLeafProof with the JSON encoded byte array given by bz
+func (p *MmrLeafProof) UnmarshalJSON(bz []byte) error {
+	var tmp mmrLeafProofJSON
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+
+	// the leaf is sent as an opaque leaf, i.e. the encoded leaf prefixed with its length
+	var leaf Bytes
+	err := DecodeFromHexString(tmp.Leaf, &leaf)
+	if err != nil {
+		return err
+	}
+	err = DecodeFromBytes(leaf, &p.Leaf)
+	if err != nil {
+		return err
+	}
+
+	p.BlockHash = tmp.BlockHash
+	return DecodeFromHexString(tmp.Proof, &p.Proof)
+}
+
+// MarshalJSON returns a JSON encoded byte array of MmrLeafProof
+func (p MmrLeafProof) MarshalJSON() ([]byte, error) {
+	leaf, err := EncodeToBytes(p.Leaf)
+	if err != nil {
+		return nil, err
+	}
+	tmp := mmrLeafProofJSON{BlockHash: p.BlockHash}
+	tmp.Leaf, err = EncodeToHexString(Bytes(leaf))
+	if err != nil {
+		return nil, err
+	}
+	tmp.Proof, err = EncodeToHexString(p.Proof)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(tmp)
+}
+
+// MmrLeafBatchProof is a list of leaves with their proof as returned by mmr_generateBatchProof
+type MmrLeafBatchProof struct {
+	// BlockHash is the hash of the block at which the proof was generated, its MMR root is the one to verify against
+	BlockHash Hash
+	Leaves    []MmrLeaf
+	Proof     MmrBatchProof
+}
+
+// UnmarshalJSON fills MmrLeafBatchProof with the JSON encoded byte array given by bz
+func (p *MmrLeafBatchProof) UnmarshalJSON(bz []byte) error {
+	var tmp mmrLeafProofJSON
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+
+	var leaves []Bytes
+	err := DecodeFromHexString(tmp.Leaves, &leaves)
+	if err != nil {
+		return err
+	}
+	p.Leaves = make([]MmrLeaf, len(leaves))
+	for i, leaf := range leaves {
+		err = DecodeFromBytes(leaf, &p.Leaves[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	p.BlockHash = tmp.BlockHash
+	return DecodeFromHexString(tmp.Proof, &p.Proof)
+}
+
+// MarshalJSON returns a JSON encoded byte array of MmrLeafBatchProof
+func (p MmrLeafBatchProof) MarshalJSON() ([]byte, error) {
+	leaves := make([]Bytes, len(p.Leaves))
+	for i, leaf := range p.Leaves {
+		enc, err := EncodeToBytes(leaf)
+		if err != nil {
+			return nil, err
+		}
+		leaves[i] = enc
+	}
+
+	var err error
+	tmp := mmrLeafProofJSON{BlockHash: p.BlockHash}
+	tmp.Leaves, err = EncodeToHexString(leaves)
+	if err != nil {
+		return nil, err
+	}
+	tmp.Proof, err = EncodeToHexString(p.Proof)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(tmp)
+}
@@ -0,0 +1,87 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var testMmrLeaf = MmrLeaf{
+	Version:               0,
+	ParentNumberAndHash:   ParentNumberAndHash{ParentNumber: 41, Hash: Hash{1, 2, 3}},
+	BeefyNextAuthoritySet: BeefyNextAuthoritySet{ID: 2, Len: 4, Root: H256{4, 5, 6}},
+	LeafExtra:             H256{7, 8, 9},
+}
+
+func TestMmrLeaf_EncodeDecode(t *testing.T) {
+	assertRoundtrip(t, testMmrLeaf)
+	assertRoundtrip(t, MmrProof{LeafIndex: 3, LeafCount: 5, Items: []H256{{1}, {2}}})
+	assertRoundtrip(t, MmrBatchProof{LeafIndices: []U64{1, 3}, LeafCount: 5, Items: []H256{{1}}})
+}
+
+func TestMmrLeafProof_JSON(t *testing.T) {
+	p := MmrLeafProof{
+		BlockHash: Hash{0xab},
+		Leaf:      testMmrLeaf,
+		Proof:     MmrProof{LeafIndex: 3, LeafCount: 5, Items: []H256{{1}, {2}}},
+	}
+	bz, err := json.Marshal(p)
+	assert.NoError(t, err)
+
+	var dec MmrLeafProof
+	err = json.Unmarshal(bz, &dec)
+	assert.NoError(t, err)
+	assert.Equal(t, p, dec)
+}
+
+func TestMmrLeafProof_UnmarshalJSON(t *testing.T) {
+	// the leaf is sent as an opaque leaf, prefixed with its length of 113 bytes
+	leaf, err := EncodeToHexString(testMmrLeaf)
+	assert.NoError(t, err)
+	bz := []byte(`{"blockHash":"0xab00000000000000000000000000000000000000000000000000000000000000",` +
+		`"leaf":"0xc501` + leaf[2:] + `",` +
+		`"proof":"0x030000000000000005000000000000000401` + "00000000000000000000000000000000000000000000000000000000000000" +
+		`"}`)
+
+	var p MmrLeafProof
+	err = json.Unmarshal(bz, &p)
+	assert.NoError(t, err)
+	assert.Equal(t, MmrLeafProof{
+		BlockHash: Hash{0xab},
+		Leaf:      testMmrLeaf,
+		Proof:     MmrProof{LeafIndex: 3, LeafCount: 5, Items: []H256{{1}}},
+	}, p)
+}
+
+func TestMmrLeafBatchProof_JSON(t *testing.T) {
+	p := MmrLeafBatchProof{
+		BlockHash: Hash{0xab},
+		Leaves:    []MmrLeaf{testMmrLeaf, {Version: 1, LeafExtra: H256{1}}},
+		Proof:     MmrBatchProof{LeafIndices: []U64{1, 3}, LeafCount: 5, Items: []H256{{1}, {2}}},
+	}
+	bz, err := json.Marshal(p)
+	assert.NoError(t, err)
+
+	var dec MmrLeafBatchProof
+	err = json.Unmarshal(bz, &dec)
+	assert.NoError(t, err)
+	assert.Equal(t, p, dec)
+}
@@ -16,16 +16,44 @@
 
 package types
 
-import "github.com/JFJun/go-substrate-rpc-client/v3/scale"
+import (
+	"fmt"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/scale"
+	"github.com/JFJun/go-substrate-rpc-client/v3/signature"
+)
 
 // MultiSignature
 type MultiSignature struct {
-	IsEd25519 bool      // 0:: Ed25519(Ed25519Signature)
-	AsEd25519 Signature // Ed25519Signature
-	IsSr25519 bool      // 1:: Sr25519(Sr25519Signature)
-	AsSr25519 Signature // Sr25519Signature
-	IsEcdsa   bool      // 2:: Ecdsa(EcdsaSignature)
-	AsEcdsa   Bytes     // EcdsaSignature
+	IsEd25519 bool           // 0:: Ed25519(Ed25519Signature)
+	AsEd25519 Signature      // Ed25519Signature
+	IsSr25519 bool           // 1:: Sr25519(Sr25519Signature)
+	AsSr25519 Signature      // Sr25519Signature
+	IsEcdsa   bool           // 2:: Ecdsa(EcdsaSignature)
+	AsEcdsa   EcdsaSignature // EcdsaSignature
+}
+
+// NewMultiSignature creates a MultiSignature of the variant matching the given signature type from a raw signature
+func NewMultiSignature(t signature.SignatureType, sig []byte) (MultiSignature, error) {
+	switch t {
+	case signature.SignatureTypeEd25519:
+		if len(sig) != 64 {
+			return MultiSignature{}, fmt.Errorf("expected ed25519 signature of 64 bytes, got %v", len(sig))
+		}
+		return MultiSignature{IsEd25519: true, AsEd25519: NewSignature(sig)}, nil
+	case signature.SignatureTypeSr25519:
+		if len(sig) != 64 {
+			return MultiSignature{}, fmt.Errorf("expected sr25519 signature of 64 bytes, got %v", len(sig))
+		}
+		return MultiSignature{IsSr25519: true, AsSr25519: NewSignature(sig)}, nil
+	case signature.SignatureTypeEcdsa:
+		if len(sig) != 65 {
+			return MultiSignature{}, fmt.Errorf("expected ecdsa signature of 65 bytes, got %v", len(sig))
+		}
+		return MultiSignature{IsEcdsa: true, AsEcdsa: NewEcdsaSignature(sig)}, nil
+	default:
+		return MultiSignature{}, fmt.Errorf("unsupported signature type %v", t)
+	}
 }
 
 func (m *MultiSignature) Decode(decoder scale.Decoder) error {
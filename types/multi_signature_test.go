@@ -24,10 +24,12 @@ import (
 
 var testMultiSig1 = MultiSignature{IsEd25519: true, AsEd25519: NewSignature(hash64)}
 var testMultiSig2 = MultiSignature{IsSr25519: true, AsSr25519: NewSignature(hash64)}
+var testMultiSig3 = MultiSignature{IsEcdsa: true, AsEcdsa: NewEcdsaSignature(append(hash64, 0x1b))}
 
 func TestMultiSignature_EncodeDecode(t *testing.T) {
 	assertRoundtrip(t, testMultiSig1)
 	assertRoundtrip(t, testMultiSig2)
+	assertRoundtrip(t, testMultiSig3)
 }
 
 func TestMultiSignature_Encode(t *testing.T) {
@@ -43,3 +45,10 @@ func TestMultiSignature_Decode(t *testing.T) {
 		{MustHexDecodeString("0x0101020304050607080900010203040506070809000102030405060708090001020304050607080900010203040506070809000102030405060708090001020304"), testMultiSig2}, //nolint:lll
 	})
 }
+
+func TestMultiSignature_Ecdsa(t *testing.T) {
+	// ecdsa signatures are encoded bare, without a length prefix
+	enc := MustHexDecodeString("0x02010203040506070809000102030405060708090001020304050607080900010203040506070809000102030405060708090001020304050607080900010203041b") //nolint:lll
+	assertEncode(t, []encodingAssert{{testMultiSig3, enc}})
+	assertDecode(t, []decodingAssert{{enc, testMultiSig3}})
+}
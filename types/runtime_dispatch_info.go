@@ -0,0 +1,167 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// RuntimeDispatchInfo is the information about the dispatch of an extrinsic as returned by payment_queryInfo
+type RuntimeDispatchInfo struct {
+	// Weight of the extrinsic
+	Weight Weight
+	// Class of the extrinsic
+	Class DispatchClass
+	// PartialFee is the inclusion fee of the extrinsic, without the tip
+	PartialFee U128
+}
+
+type runtimeDispatchInfoJSON struct {
+	Weight     json.RawMessage   `json:"weight"`
+	Class      dispatchClassJSON `json:"class"`
+	PartialFee json.RawMessage   `json:"partialFee"`
+}
+
+// UnmarshalJSON fills RuntimeDispatchInfo with the JSON encoded byte array given by bz. The weight is either a number
+// or, since weights v2, an object of which the ref time is used.
+func (r *RuntimeDispatchInfo) UnmarshalJSON(bz []byte) error {
+	var tmp runtimeDispatchInfoJSON
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+
+	weight, err := unmarshalWeight(tmp.Weight)
+	if err != nil {
+		return err
+	}
+	fee, err := unmarshalNumberOrHex(tmp.PartialFee)
+	if err != nil {
+		return err
+	}
+
+	r.Weight = weight
+	r.Class = DispatchClass(tmp.Class)
+	r.PartialFee = NewU128(*fee)
+	return nil
+}
+
+// MarshalJSON returns a JSON encoded byte array of RuntimeDispatchInfo
+func (r RuntimeDispatchInfo) MarshalJSON() ([]byte, error) {
+	weight, err := json.Marshal(uint64(r.Weight))
+	if err != nil {
+		return nil, err
+	}
+	fee, err := json.Marshal(bigIntString(r.PartialFee.Int))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(runtimeDispatchInfoJSON{Weight: weight, Class: dispatchClassJSON(r.Class), PartialFee: fee})
+}
+
+// dispatchClassJSON is a DispatchClass serialized as its lowercase name, as done by RPCs such as payment_queryInfo
+type dispatchClassJSON DispatchClass
+
+// UnmarshalJSON fills dispatchClassJSON with the JSON encoded byte array given by bz
+func (d *dispatchClassJSON) UnmarshalJSON(bz []byte) error {
+	var tmp string
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return err
+	}
+
+	*d = dispatchClassJSON{}
+	switch tmp {
+	case "normal":
+		d.IsNormal = true
+	case "operational":
+		d.IsOperational = true
+	case "mandatory":
+		d.IsMandatory = true
+	default:
+		return fmt.Errorf("unknown DispatchClass %v", tmp)
+	}
+	return nil
+}
+
+// MarshalJSON returns a JSON encoded byte array of dispatchClassJSON
+func (d dispatchClassJSON) MarshalJSON() ([]byte, error) {
+	switch {
+	case d.IsOperational:
+		return json.Marshal("operational")
+	case d.IsMandatory:
+		return json.Marshal("mandatory")
+	default:
+		return json.Marshal("normal")
+	}
+}
+
+// unmarshalWeight decodes a weight given as a number or as an object with a ref time
+func unmarshalWeight(bz []byte) (Weight, error) {
+	var weight uint64
+	if err := json.Unmarshal(bz, &weight); err == nil {
+		return Weight(weight), nil
+	}
+
+	var tmp struct {
+		RefTime      *uint64 `json:"ref_time"`
+		RefTimeCamel *uint64 `json:"refTime"`
+	}
+	if err := json.Unmarshal(bz, &tmp); err != nil {
+		return 0, err
+	}
+	switch {
+	case tmp.RefTime != nil:
+		return Weight(*tmp.RefTime), nil
+	case tmp.RefTimeCamel != nil:
+		return Weight(*tmp.RefTimeCamel), nil
+	default:
+		return 0, fmt.Errorf("invalid weight %s", bz)
+	}
+}
+
+// unmarshalNumberOrHex decodes a balance as serialized by the node, which is either a JSON number, a decimal string or
+// a hex string prefixed with 0x
+func unmarshalNumberOrHex(bz []byte) (*big.Int, error) {
+	s := string(bz)
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(bz, &s); err != nil {
+			return nil, err
+		}
+	}
+
+	i := new(big.Int)
+	var ok bool
+	if strings.HasPrefix(s, "0x") {
+		_, ok = i.SetString(s[2:], 16)
+	} else {
+		_, ok = i.SetString(s, 10)
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid number %v", string(bz))
+	}
+	return i, nil
+}
+
+// bigIntString returns the decimal representation of i, treating nil as zero
+func bigIntString(i *big.Int) string {
+	if i == nil {
+		return "0"
+	}
+	return i.String()
+}
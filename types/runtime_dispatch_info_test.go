@@ -0,0 +1,82 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	. "github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuntimeDispatchInfo_UnmarshalJSON(t *testing.T) {
+	var info RuntimeDispatchInfo
+	err := json.Unmarshal([]byte(`{"weight":195000000,"class":"normal","partialFee":"15600000001"}`), &info)
+	assert.NoError(t, err)
+	assert.Equal(t, RuntimeDispatchInfo{
+		Weight:     195000000,
+		Class:      DispatchClass{IsNormal: true},
+		PartialFee: NewU128(*big.NewInt(15600000001)),
+	}, info)
+
+	// weights v2 and fees given as numbers
+	err = json.Unmarshal([]byte(`{"weight":{"ref_time":1000,"proof_size":10},"class":"operational",
+		"partialFee":42}`), &info)
+	assert.NoError(t, err)
+	assert.Equal(t, RuntimeDispatchInfo{
+		Weight:     1000,
+		Class:      DispatchClass{IsOperational: true},
+		PartialFee: NewU128(*big.NewInt(42)),
+	}, info)
+
+	err = json.Unmarshal([]byte(`{"weight":1,"class":"unknown","partialFee":"1"}`), &info)
+	assert.EqualError(t, err, "unknown DispatchClass unknown")
+}
+
+func TestRuntimeDispatchInfo_JSONRoundtrip(t *testing.T) {
+	info := RuntimeDispatchInfo{
+		Weight:     195000000,
+		Class:      DispatchClass{IsMandatory: true},
+		PartialFee: NewU128(*big.NewInt(15600000001)),
+	}
+	bz, err := json.Marshal(info)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"weight":195000000,"class":"mandatory","partialFee":"15600000001"}`, string(bz))
+
+	var dec RuntimeDispatchInfo
+	err = json.Unmarshal(bz, &dec)
+	assert.NoError(t, err)
+	assert.Equal(t, info, dec)
+}
+
+func TestDispatchInfo_MarshalJSON(t *testing.T) {
+	// only RuntimeDispatchInfo serializes the class by its name
+	bz, err := json.Marshal(DispatchInfo{Weight: 10, Class: DispatchClass{IsOperational: true}, PaysFee: Pays{IsYes: true}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"Weight":10,"Class":{"IsNormal":false,"IsOperational":true,"IsMandatory":false},`+
+		`"PaysFee":{"IsYes":true,"IsNo":false}}`, string(bz))
+}
+
+func TestRuntimeDispatchInfo_EncodeDecode(t *testing.T) {
+	assertRoundtrip(t, RuntimeDispatchInfo{
+		Weight:     195000000,
+		Class:      DispatchClass{IsNormal: true},
+		PartialFee: NewU128(*big.NewInt(15600000001)),
+	})
+}
@@ -32,3 +32,18 @@ func NewSignature(b []byte) Signature {
 func (h Signature) Hex() string {
 	return fmt.Sprintf("%#x", h[:])
 }
+
+// EcdsaSignature is a recoverable secp256k1 signature of 65 bytes, the last one being the recovery id
+type EcdsaSignature [65]byte
+
+// NewEcdsaSignature creates a new EcdsaSignature type
+func NewEcdsaSignature(b []byte) EcdsaSignature {
+	s := EcdsaSignature{}
+	copy(s[:], b)
+	return s
+}
+
+// Hex returns a hex string representation of the value (not of the encoded value)
+func (s EcdsaSignature) Hex() string {
+	return fmt.Sprintf("%#x", s[:])
+}
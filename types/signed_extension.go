@@ -0,0 +1,169 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/scale"
+)
+
+// SignedExtensionEncoder returns the values of a signed extension for the given SignatureOptions: extra is included in
+// the extrinsic, additional is only part of the signing payload. Both are converted with MetadataV14.NewValue into the
+// types the metadata declares for the extension, nil stands for an empty value.
+type SignedExtensionEncoder func(o SignatureOptions) (extra interface{}, additional interface{}, err error)
+
+var (
+	signedExtensionsLk sync.RWMutex
+	signedExtensions   = map[string]SignedExtensionEncoder{
+		"CheckSpecVersion": func(o SignatureOptions) (interface{}, interface{}, error) {
+			return nil, o.SpecVersion, nil
+		},
+		"CheckTxVersion": func(o SignatureOptions) (interface{}, interface{}, error) {
+			return nil, o.TransactionVersion, nil
+		},
+		"CheckGenesis": func(o SignatureOptions) (interface{}, interface{}, error) {
+			return nil, o.GenesisHash, nil
+		},
+		"CheckMortality": checkMortality,
+		"CheckEra":       checkMortality,
+		"CheckNonce": func(o SignatureOptions) (interface{}, interface{}, error) {
+			return o.Nonce, nil, nil
+		},
+		"CheckWeight": emptySignedExtension,
+		"ChargeTransactionPayment": func(o SignatureOptions) (interface{}, interface{}, error) {
+			return o.Tip, nil, nil
+		},
+		"ChargeAssetTxPayment": func(o SignatureOptions) (interface{}, interface{}, error) {
+			// pays fees in the native asset, register a custom encoder to pay with another asset
+			return map[string]interface{}{"tip": o.Tip, "asset_id": map[string]interface{}{"None": nil}}, nil, nil
+		},
+		"CheckMetadataHash": func(o SignatureOptions) (interface{}, interface{}, error) {
+			return map[string]interface{}{"mode": "Disabled"}, map[string]interface{}{"None": nil}, nil
+		},
+	}
+)
+
+func checkMortality(o SignatureOptions) (interface{}, interface{}, error) {
+	era := o.Era
+	if !o.Era.IsMortalEra {
+		era = ExtrinsicEra{IsImmortalEra: true}
+	}
+	return era, o.BlockHash, nil
+}
+
+func emptySignedExtension(SignatureOptions) (interface{}, interface{}, error) {
+	return nil, nil, nil
+}
+
+// RegisterSignedExtension registers the encoder for the signed extension with the given identifier, replacing the
+// default encoder if there is one. Extensions whose extra and additional types are both empty need no encoder.
+func RegisterSignedExtension(identifier string, encoder SignedExtensionEncoder) {
+	signedExtensionsLk.Lock()
+	defer signedExtensionsLk.Unlock()
+	signedExtensions[identifier] = encoder
+}
+
+// EncodeSignedExtensions encodes the extra and additional signed data of all signed extensions the metadata declares,
+// in the order of ExtrinsicMetadataV14.SignedExtensions
+func (d *MetadataV14) EncodeSignedExtensions(o SignatureOptions) (extra []byte, additional []byte, err error) {
+	var extraBuf, additionalBuf = bytes.Buffer{}, bytes.Buffer{}
+	extraEnc, additionalEnc := scale.NewEncoder(&extraBuf), scale.NewEncoder(&additionalBuf)
+
+	for _, ext := range d.Extrinsic.SignedExtensions {
+		signedExtensionsLk.RLock()
+		encoder, ok := signedExtensions[string(ext.Identifier)]
+		signedExtensionsLk.RUnlock()
+		if !ok {
+			if !d.isEmptyType(ext.Type.Int64()) || !d.isEmptyType(ext.AdditionalSigned.Int64()) {
+				return nil, nil, fmt.Errorf("no encoder registered for signed extension %v", ext.Identifier)
+			}
+			encoder = emptySignedExtension
+		}
+
+		extraArg, additionalArg, err := encoder(o)
+		if err != nil {
+			return nil, nil, fmt.Errorf("signed extension %v: %v", ext.Identifier, err)
+		}
+		err = d.encodeSignedExtensionValue(*extraEnc, ext.Type.Int64(), extraArg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to encode extra of signed extension %v: %v", ext.Identifier, err)
+		}
+		err = d.encodeSignedExtensionValue(*additionalEnc, ext.AdditionalSigned.Int64(), additionalArg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to encode additional signed of signed extension %v: %v",
+				ext.Identifier, err)
+		}
+	}
+	return extraBuf.Bytes(), additionalBuf.Bytes(), nil
+}
+
+// DecodeSignedExtensions decodes the extra data of all signed extensions the metadata declares into the signature,
+// setting Extra to its encoding. Era, Nonce and Tip are filled from CheckMortality, CheckNonce and
+// ChargeTransactionPayment or ChargeAssetTxPayment if the metadata declares them.
+func (d *MetadataV14) DecodeSignedExtensions(decoder scale.Decoder, s *ExtrinsicSignatureV4) error {
+	s.HasExtra = true
+	s.Extra = BytesBare{}
+
+	for _, ext := range d.Extrinsic.SignedExtensions {
+		v, err := d.DecodeValue(decoder, ext.Type.Int64())
+		if err != nil {
+			return fmt.Errorf("unable to decode extra of signed extension %v: %v", ext.Identifier, err)
+		}
+		raw, err := EncodeWithType(d, ext.Type.Int64(), v)
+		if err != nil {
+			return fmt.Errorf("unable to encode extra of signed extension %v: %v", ext.Identifier, err)
+		}
+		s.Extra = append(s.Extra, raw...)
+
+		switch ext.Identifier {
+		case "CheckMortality", "CheckEra":
+			err = DecodeFromBytes(raw, &s.Era)
+		case "CheckNonce":
+			err = DecodeFromBytes(raw, &s.Nonce)
+		case "ChargeTransactionPayment", "ChargeAssetTxPayment":
+			// the tip is the first field of ChargeAssetTxPayment
+			err = scale.NewDecoder(bytes.NewReader(raw)).Decode(&s.Tip)
+		}
+		if err != nil {
+			return fmt.Errorf("unable to decode extra of signed extension %v: %v", ext.Identifier, err)
+		}
+	}
+	return nil
+}
+
+func (d *MetadataV14) encodeSignedExtensionValue(encoder scale.Encoder, id int64, arg interface{}) error {
+	if arg == nil && d.isEmptyType(id) {
+		return nil
+	}
+	v, err := d.NewValue(id, arg)
+	if err != nil {
+		return err
+	}
+	return d.EncodeValue(encoder, id, v)
+}
+
+// isEmptyType returns true if the type is an empty struct or tuple, which is encoded as nothing
+func (d *MetadataV14) isEmptyType(id int64) bool {
+	typ, err := d.FindType(id)
+	if err != nil {
+		return false
+	}
+	return (typ.Def.IsComposite && len(typ.Def.Composite.Fields) == 0) || (typ.Def.IsTuple && len(typ.Def.Tuple) == 0)
+}
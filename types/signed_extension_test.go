@@ -0,0 +1,72 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+var exampleSignatureOptions = SignatureOptions{
+	BlockHash:          NewHash(MustHexDecodeString("0xec7afaf1cca720ce88c1d1b689d81f0583cc15a97d621cf046dd9abf605ef22f")),
+	GenesisHash:        NewHash(MustHexDecodeString("0xdcd1346701ca8396496e52aa2785b1748deb6db09551b72159dcb3e08991025b")),
+	Nonce:              NewUCompactFromUInt(1),
+	SpecVersion:        9110,
+	Tip:                NewUCompactFromUInt(2),
+	TransactionVersion: 8,
+}
+
+func TestMetadataV14_EncodeSignedExtensions(t *testing.T) {
+	meta := exampleMetadataV14(t)
+
+	extra, additional, err := meta.EncodeSignedExtensions(exampleSignatureOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, MustHexDecodeString("0x000408"), extra)
+	assert.Equal(t, MustHexDecodeString("0x96230000"+"08000000"+
+		"dcd1346701ca8396496e52aa2785b1748deb6db09551b72159dcb3e08991025b"+
+		"ec7afaf1cca720ce88c1d1b689d81f0583cc15a97d621cf046dd9abf605ef22f"), additional)
+}
+
+func TestMetadataV14_EncodeSignedExtensions_Custom(t *testing.T) {
+	meta := exampleMetadataV14(t)
+	empty := meta.Extrinsic.SignedExtensions[0].Type
+	u32 := meta.Extrinsic.SignedExtensions[0].AdditionalSigned
+	meta.Extrinsic.SignedExtensions = []SignedExtensionMetadataV14{
+		{Identifier: "CheckEmptyTestExtension", Type: empty, AdditionalSigned: empty},
+		{Identifier: "CheckCustomTestExtension", Type: u32, AdditionalSigned: empty},
+	}
+
+	_, _, err := meta.EncodeSignedExtensions(exampleSignatureOptions)
+	assert.EqualError(t, err, "no encoder registered for signed extension CheckCustomTestExtension")
+
+	RegisterSignedExtension("CheckCustomTestExtension", func(o SignatureOptions) (interface{}, interface{}, error) {
+		return "foo", nil, nil
+	})
+	_, _, err = meta.EncodeSignedExtensions(exampleSignatureOptions)
+	assert.EqualError(t, err, "unable to encode extra of signed extension CheckCustomTestExtension: "+
+		"expected a U32, but got string")
+
+	RegisterSignedExtension("CheckCustomTestExtension", func(o SignatureOptions) (interface{}, interface{}, error) {
+		return 7, nil, nil
+	})
+	extra, additional, err := meta.EncodeSignedExtensions(exampleSignatureOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{7, 0, 0, 0}, extra)
+	assert.Empty(t, additional)
+}
@@ -0,0 +1,125 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+// SS58PrefixSubstrate is the SS58 prefix of generic substrate addresses, used if a chain does not define its own
+const SS58PrefixSubstrate = 42
+
+// ss58MaxPrefix is the first prefix that can not be encoded in the two byte format
+const ss58MaxPrefix = 1 << 14
+
+const ss58ChecksumLength = 2
+
+var ss58ChecksumPrefix = []byte("SS58PRE")
+
+// ToSS58 returns the SS58 address of the AccountID for the network with the given prefix. Prefixes from 64 to 16383
+// use the two byte format.
+// See https://github.com/paritytech/substrate/wiki/External-Address-Format-(SS58)
+func (a AccountID) ToSS58(prefix uint16) (string, error) {
+	return EncodeSS58(prefix, a[:])
+}
+
+// NewAccountIDFromSS58 creates an AccountID from an SS58 address, verifying its checksum. Use DecodeSS58 to also
+// obtain the network prefix of the address.
+func NewAccountIDFromSS58(address string) (AccountID, error) {
+	_, payload, err := DecodeSS58(address)
+	if err != nil {
+		return AccountID{}, err
+	}
+	if len(payload) != len(AccountID{}) {
+		return AccountID{}, fmt.Errorf("expected an account id of %v bytes in SS58 address, got %v", len(AccountID{}),
+			len(payload))
+	}
+	return NewAccountID(payload), nil
+}
+
+// SS58Prefix returns the SS58 prefix of the chain, or SS58PrefixSubstrate if the chain does not define one
+func (a ChainProperties) SS58Prefix() uint16 {
+	if !a.IsSS58Format {
+		return SS58PrefixSubstrate
+	}
+	return uint16(a.AsSS58Format)
+}
+
+// EncodeSS58 encodes the payload, usually an account id, with the given network prefix into an SS58 address
+func EncodeSS58(prefix uint16, payload []byte) (string, error) {
+	var data []byte
+	switch {
+	case prefix < 64:
+		data = []byte{byte(prefix)}
+	case prefix < ss58MaxPrefix:
+		// the lower six bits of the first byte hold bits 2..7 of the prefix, the second byte holds bits 0..1 in its
+		// upper two bits and bits 8..13 in its lower six bits
+		data = []byte{
+			byte((prefix&0xfc)>>2) | 0x40,
+			byte(prefix>>8) | byte(prefix&0x03)<<6,
+		}
+	default:
+		return "", fmt.Errorf("SS58 prefix %v out of range", prefix)
+	}
+
+	data = append(data, payload...)
+	checksum := ss58Checksum(data)
+	return base58.Encode(append(data, checksum[:ss58ChecksumLength]...)), nil
+}
+
+// DecodeSS58 decodes an SS58 address into its network prefix and payload, verifying its checksum
+func DecodeSS58(address string) (prefix uint16, payload []byte, err error) {
+	data := base58.Decode(address)
+	if len(data) == 0 {
+		return 0, nil, fmt.Errorf("invalid base58 in SS58 address %v", address)
+	}
+
+	var prefixLength int
+	switch {
+	case data[0] < 64:
+		prefix, prefixLength = uint16(data[0]), 1
+	case data[0] < 128:
+		if len(data) < 2 {
+			return 0, nil, fmt.Errorf("SS58 address %v too short", address)
+		}
+		lower := (data[0]<<2)&0xfc | data[1]>>6
+		upper := data[1] & 0x3f
+		prefix, prefixLength = uint16(lower)|uint16(upper)<<8, 2
+	default:
+		return 0, nil, fmt.Errorf("invalid prefix byte %v in SS58 address %v", data[0], address)
+	}
+
+	if len(data) < prefixLength+ss58ChecksumLength+1 {
+		return 0, nil, fmt.Errorf("SS58 address %v too short", address)
+	}
+
+	body, checksum := data[:len(data)-ss58ChecksumLength], data[len(data)-ss58ChecksumLength:]
+	expected := ss58Checksum(body)
+	if !bytes.Equal(checksum, expected[:ss58ChecksumLength]) {
+		return 0, nil, fmt.Errorf("invalid checksum in SS58 address %v", address)
+	}
+
+	return prefix, body[prefixLength:], nil
+}
+
+func ss58Checksum(data []byte) [blake2b.Size]byte {
+	return blake2b.Sum512(append(append([]byte{}, ss58ChecksumPrefix...), data...))
+}
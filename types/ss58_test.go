@@ -0,0 +1,81 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	. "github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/assert"
+)
+
+var exampleSS58AccountID = NewAccountID(
+	MustHexDecodeString("0xdc64bef918ddda3126a39a11113767741ddfdf91399f055e1d963f2ae1ec2535"))
+
+func TestAccountID_ToSS58(t *testing.T) {
+	for prefix, address := range map[uint16]string{
+		0:  "15yyTpfXxzvqhCNniKWrMGeFrhjPNQxfy5ccgLUKGY1THbTW",
+		2:  "HZHyokLjagJ1KBiXPGu75B79g1yUnDiLxisuhkvCFCRrWBk",
+		42: "5H3gKVQU7DfNFfNGkgTrD7p715jjg7QXtat8X3UxiSyw7APW",
+	} {
+		res, err := exampleSS58AccountID.ToSS58(prefix)
+		assert.NoError(t, err)
+		assert.Equal(t, address, res)
+
+		accountID, err := NewAccountIDFromSS58(address)
+		assert.NoError(t, err)
+		assert.Equal(t, exampleSS58AccountID, accountID)
+	}
+}
+
+func TestAccountID_ToSS58_TwoBytePrefix(t *testing.T) {
+	address, err := exampleSS58AccountID.ToSS58(64)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x50, 0x00}, base58.Decode(address)[:2])
+
+	for _, prefix := range []uint16{64, 255, 1284, 16383} {
+		address, err := exampleSS58AccountID.ToSS58(prefix)
+		assert.NoError(t, err)
+
+		decodedPrefix, payload, err := DecodeSS58(address)
+		assert.NoError(t, err)
+		assert.Equal(t, prefix, decodedPrefix)
+		assert.Equal(t, exampleSS58AccountID[:], payload)
+	}
+
+	_, err = exampleSS58AccountID.ToSS58(16384)
+	assert.EqualError(t, err, "SS58 prefix 16384 out of range")
+}
+
+func TestNewAccountIDFromSS58_Invalid(t *testing.T) {
+	_, err := NewAccountIDFromSS58("5H3gKVQU7DfNFfNGkgTrD7p715jjg7QXtat8X3UxiSyw7APX")
+	assert.EqualError(t, err, "invalid checksum in SS58 address 5H3gKVQU7DfNFfNGkgTrD7p715jjg7QXtat8X3UxiSyw7APX")
+
+	_, err = NewAccountIDFromSS58("0OIl")
+	assert.Error(t, err)
+
+	short, err := EncodeSS58(42, []byte{1, 2, 3})
+	assert.NoError(t, err)
+	_, err = NewAccountIDFromSS58(short)
+	assert.EqualError(t, err, "expected an account id of 32 bytes in SS58 address, got 3")
+}
+
+func TestChainProperties_SS58Prefix(t *testing.T) {
+	assert.Equal(t, uint16(SS58PrefixSubstrate), ChainProperties{}.SS58Prefix())
+	assert.Equal(t, uint16(2), ChainProperties{IsSS58Format: true, AsSS58Format: 2}.SS58Prefix())
+}
@@ -34,7 +34,8 @@ type KeyValueOption struct {
 }
 
 func (r *KeyValueOption) UnmarshalJSON(b []byte) error {
-	var tmp []string
+	// the data of a key is null if it has been removed or, for state_queryStorageAt, if it is not set
+	var tmp []*string
 	if err := json.Unmarshal(b, &tmp); err != nil {
 		return err
 	}
@@ -42,15 +43,20 @@ func (r *KeyValueOption) UnmarshalJSON(b []byte) error {
 	case 0:
 		return fmt.Errorf("expected at least one entry for KeyValueOption")
 	case 2:
-		r.HasStorageData = true
-		data, err := HexDecodeString(tmp[1])
-		if err != nil {
-			return err
+		if tmp[1] != nil {
+			r.HasStorageData = true
+			data, err := HexDecodeString(*tmp[1])
+			if err != nil {
+				return err
+			}
+			r.StorageData = data
 		}
-		r.StorageData = data
 		fallthrough
 	case 1:
-		key, err := HexDecodeString(tmp[0])
+		if tmp[0] == nil {
+			return fmt.Errorf("expected a storage key for KeyValueOption, got null")
+		}
+		key, err := HexDecodeString(*tmp[0])
 		if err != nil {
 			return err
 		}
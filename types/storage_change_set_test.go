@@ -81,6 +81,20 @@ func TestKeyValueOption_UnmarshalJSON2(t *testing.T) {
 	}, kv)
 }
 
+func TestKeyValueOption_UnmarshalJSONNull(t *testing.T) {
+	s := []byte("[\"0xcc956bdb7605e3547539f321ac2bc95c\",null]")
+
+	var kv KeyValueOption
+
+	err := json.Unmarshal(s, &kv)
+	assert.NoError(t, err)
+
+	assert.Equal(t, KeyValueOption{
+		StorageKey:     MustHexDecodeString("0xcc956bdb7605e3547539f321ac2bc95c"),
+		HasStorageData: false,
+	}, kv)
+}
+
 func TestKeyValueOption_UnmarshalMarshalJSON(t *testing.T) {
 	s := []byte("[\"0xcc956bdb7605e3547539f321ac2bc95c\",\"0x0800000000000000000001000000000000\"]")
 
@@ -17,6 +17,7 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 
@@ -55,6 +56,160 @@ func CreateStorageKey(meta *Metadata, prefix, method string, args ...[]byte) (St
 	return createKey(meta, method, prefix, stringKey, args[0], entryMeta)
 }
 
+// CreateStoragePrefix returns the prefix shared by all keys of the given storage entry, the twox128 hashes of prefix
+// and method. Use it with state_getKeysPaged to iterate all keys of a storage map.
+func CreateStoragePrefix(prefix, method string) StorageKey {
+	return createPrefixedKey(method, prefix)
+}
+
+// DecodeStorageKey recovers the map keys from a full storage key of the given storage entry, for example as returned
+// by state_getKeys, and decodes each of them with its type from the MetadataV14 type registry. Only keys hashed with
+// Blake2_128Concat, Twox64Concat or Identity contain the original key and can be decoded. Plain storage entries have
+// no map keys and yield an empty slice.
+func DecodeStorageKey(meta *Metadata, prefix, method string, key StorageKey) ([]Value, error) {
+	if !meta.IsMetadataV14 {
+		return nil, fmt.Errorf("decoding storage keys requires metadata v14, got v%v", meta.Version)
+	}
+
+	entry, err := meta.AsMetadataV14.FindStorageEntryMetadata(prefix, method)
+	if err != nil {
+		return nil, err
+	}
+	entryMeta := entry.(StorageEntryMetadataV14)
+
+	prefixedKey := createPrefixedKey(method, prefix)
+	if !bytes.HasPrefix(key, prefixedKey) {
+		return nil, fmt.Errorf("key %v is not a key of %v.%v", key.Hex(), prefix, method)
+	}
+	data := key[len(prefixedKey):]
+
+	if !entryMeta.Type.IsMap {
+		if len(data) > 0 {
+			return nil, fmt.Errorf("%v.%v is a plain storage entry, but key %v has %v additional bytes", prefix,
+				method, key.Hex(), len(data))
+		}
+		return []Value{}, nil
+	}
+
+	hashers := entryMeta.Type.AsMap.Hasher
+	keyIDs, err := meta.AsMetadataV14.storageKeyTypeIDs(entryMeta.Type.AsMap)
+	if err != nil {
+		return nil, fmt.Errorf("%v.%v: %v", prefix, method, err)
+	}
+
+	reader := bytes.NewReader(data)
+	values := make([]Value, len(hashers))
+	for i, hasher := range hashers {
+		hashStart := len(data) - reader.Len()
+
+		var hashLen int64
+		switch {
+		case hasher.IsBlake2_128Concat:
+			hashLen = 16
+		case hasher.IsTwox64Concat:
+			hashLen = 8
+		case hasher.IsIdentity:
+			hashLen = 0
+		default:
+			return nil, fmt.Errorf("key %v of %v.%v is hashed with a hasher that does not contain the original key", i,
+				prefix, method)
+		}
+
+		_, err = reader.Seek(hashLen, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+
+		values[i], err = meta.AsMetadataV14.DecodeValue(*scale.NewDecoder(reader), keyIDs[i])
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode key %v of %v.%v: %v", i, prefix, method, err)
+		}
+
+		// the decoded key must hash to the bytes it was read from
+		h, err := hasher.HashFunc()
+		if err != nil {
+			return nil, err
+		}
+		hashed := data[hashStart : len(data)-reader.Len()]
+		_, err = h.Write(hashed[hashLen:])
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(h.Sum(nil), hashed) {
+			return nil, fmt.Errorf("hash of key %v of %v.%v does not match in %v", i, prefix, method, key.Hex())
+		}
+	}
+
+	if reader.Len() > 0 {
+		return nil, fmt.Errorf("%v bytes left after decoding the keys of %v.%v from %v", reader.Len(), prefix, method,
+			key.Hex())
+	}
+
+	return values, nil
+}
+
+// FindStorageDefault returns the default value of the storage entry the key belongs to, which the runtime returns for
+// absent keys of entries with the Default modifier. Ok is false if the entry has no default, i.e. it is optional.
+func (m *Metadata) FindStorageDefault(key StorageKey) (fallback Bytes, ok bool, err error) {
+	if !m.IsMetadataV14 {
+		return nil, false, fmt.Errorf("finding storage defaults requires metadata v14, got v%v", m.Version)
+	}
+
+	for _, pallet := range m.AsMetadataV14.Pallets {
+		if !pallet.HasStorage {
+			continue
+		}
+		for _, entry := range pallet.Storage.Items {
+			if !bytes.HasPrefix(key, CreateStoragePrefix(string(pallet.Storage.Prefix), string(entry.Name))) {
+				continue
+			}
+			if !entry.Modifier.IsDefault {
+				return nil, false, nil
+			}
+			return entry.Fallback, true, nil
+		}
+	}
+	return nil, false, fmt.Errorf("no storage entry found in metadata for key %v", key.Hex())
+}
+
+// DecodeStorageWithDefault decodes the value raw of the given key into target. If raw is empty, i.e. the key is
+// absent, the default value of its storage entry is decoded instead, as the runtime would do. Ok is false if neither
+// exists.
+func (m *Metadata) DecodeStorageWithDefault(key StorageKey, raw StorageDataRaw, target interface{}) (ok bool,
+	err error) {
+	if len(raw) == 0 {
+		fallback, ok, err := m.FindStorageDefault(key)
+		if err != nil || !ok {
+			return false, err
+		}
+		raw = StorageDataRaw(fallback)
+	}
+	return true, DecodeFromBytes(raw, target)
+}
+
+// storageKeyTypeIDs returns the type ids of the keys of a storage map, which are the elements of the KeysId tuple if
+// the map has more than one hasher
+func (d *MetadataV14) storageKeyTypeIDs(m MapTypeV14) ([]int64, error) {
+	if len(m.Hasher) == 1 {
+		return []int64{m.KeysId.Int64()}, nil
+	}
+
+	typ, err := d.FindType(m.KeysId.Int64())
+	if err != nil {
+		return nil, err
+	}
+	if !typ.Def.IsTuple || len(typ.Def.Tuple) != len(m.Hasher) {
+		return nil, fmt.Errorf("expected the keys type %v to be a tuple of %v elements", m.KeysId.Int64(),
+			len(m.Hasher))
+	}
+
+	ids := make([]int64, len(typ.Def.Tuple))
+	for i, id := range typ.Def.Tuple {
+		ids[i] = id.Int64()
+	}
+	return ids, nil
+}
+
 // Encode implements encoding for StorageKey, which just unwraps the bytes of StorageKey
 func (s StorageKey) Encode(encoder scale.Encoder) error {
 	return encoder.Write(s)
@@ -186,3 +186,133 @@ func TestStorageKey_Eq(t *testing.T) {
 		{NewStorageKey([]byte{0}), NewBool(false), false},
 	})
 }
+
+func TestDecodeStorageKey_Blake2_128Concat(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	alice := MustHexDecodeString(AlicePubKey)
+	key, err := CreateStorageKey(&meta, "System", "Account", alice)
+	assert.NoError(t, err)
+
+	keys, err := DecodeStorageKey(&meta, "System", "Account", key)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	// AccountId32 is a composite wrapping a byte array
+	bz, ok := keys[0].Fields[0].Value.Bytes()
+	assert.True(t, ok)
+	assert.Equal(t, alice, bz)
+}
+
+func TestDecodeStorageKey_Twox64ConcatDoubleMap(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	era, err := EncodeToBytes(U32(1234))
+	assert.NoError(t, err)
+	alice := MustHexDecodeString(AlicePubKey)
+	key, err := CreateStorageKey(&meta, "Staking", "ErasStakers", era, alice)
+	assert.NoError(t, err)
+
+	keys, err := DecodeStorageKey(&meta, "Staking", "ErasStakers", key)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+	assert.Equal(t, U32(1234), keys[0].Primitive)
+	bz, ok := keys[1].Fields[0].Value.Bytes()
+	assert.True(t, ok)
+	assert.Equal(t, alice, bz)
+}
+
+func TestDecodeStorageKey_Identity(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	hash := MustHexDecodeString("0x0102030405060708091011121314151617181920212223242526272829303132")
+	key, err := CreateStorageKey(&meta, "Council", "ProposalOf", hash)
+	assert.NoError(t, err)
+
+	keys, err := DecodeStorageKey(&meta, "Council", "ProposalOf", key)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	// H256 is a composite wrapping a byte array
+	bz, ok := keys[0].Fields[0].Value.Bytes()
+	assert.True(t, ok)
+	assert.Equal(t, hash, bz)
+}
+
+func TestDecodeStorageKey_Errors(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	key, err := CreateStorageKey(&meta, "System", "Account", MustHexDecodeString(AlicePubKey))
+	assert.NoError(t, err)
+
+	_, err = DecodeStorageKey(&meta, "Balances", "Account", key)
+	assert.EqualError(t, err, "key "+key.Hex()+" is not a key of Balances.Account")
+
+	corrupted := append(StorageKey{}, key...)
+	corrupted[len(corrupted)-1] ^= 0xff
+	_, err = DecodeStorageKey(&meta, "System", "Account", corrupted)
+	assert.EqualError(t, err, "hash of key 0 of System.Account does not match in "+corrupted.Hex())
+
+	_, err = DecodeStorageKey(&meta, "System", "Account", append(key, 0x00))
+	assert.Error(t, err)
+
+	_, err = DecodeStorageKey(ExamplaryMetadataV10, "System", "Account", key)
+	assert.EqualError(t, err, "decoding storage keys requires metadata v14, got v10")
+}
+
+func TestFindStorageDefault(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	key, err := CreateStorageKey(&meta, "System", "Account", MustHexDecodeString(AlicePubKey))
+	assert.NoError(t, err)
+	fallback, ok, err := meta.FindStorageDefault(key)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, make(Bytes, 80), fallback)
+
+	key, err = CreateStorageKey(&meta, "Staking", "Bonded", MustHexDecodeString(AlicePubKey))
+	assert.NoError(t, err)
+	_, ok, err = meta.FindStorageDefault(key)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = meta.FindStorageDefault(StorageKey{0x01, 0x02})
+	assert.EqualError(t, err, "no storage entry found in metadata for key 0x0102")
+}
+
+func TestMetadata_DecodeStorageWithDefault(t *testing.T) {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+
+	key, err := CreateStorageKey(&meta, "System", "Account", MustHexDecodeString(AlicePubKey))
+	assert.NoError(t, err)
+	var nonce U32
+	ok, err := meta.DecodeStorageWithDefault(key, StorageDataRaw{0x01, 0x00, 0x00, 0x00}, &nonce)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, U32(1), nonce)
+
+	// absent keys decode the default
+	info := AccountInfo{Nonce: 5}
+	ok, err = meta.DecodeStorageWithDefault(key, nil, &info)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, U32(0), info.Nonce)
+	assert.Equal(t, uint64(0), info.Data.Free.Uint64())
+
+	key, err = CreateStorageKey(&meta, "Staking", "Bonded", MustHexDecodeString(AlicePubKey))
+	assert.NoError(t, err)
+	var controller AccountID
+	ok, err = meta.DecodeStorageWithDefault(key, nil, &controller)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
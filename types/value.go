@@ -0,0 +1,649 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/scale"
+)
+
+// ValueKind describes which Si1TypeDef a Value was decoded from
+type ValueKind uint8
+
+const (
+	ValueKindPrimitive ValueKind = iota
+	ValueKindComposite
+	ValueKindVariant
+	ValueKindSequence
+	ValueKindArray
+	ValueKindTuple
+	ValueKindCompact
+	ValueKindBitSequence
+)
+
+func (k ValueKind) String() string {
+	switch k {
+	case ValueKindPrimitive:
+		return "primitive"
+	case ValueKindComposite:
+		return "composite"
+	case ValueKindVariant:
+		return "variant"
+	case ValueKindSequence:
+		return "sequence"
+	case ValueKindArray:
+		return "array"
+	case ValueKindTuple:
+		return "tuple"
+	case ValueKindCompact:
+		return "compact"
+	case ValueKindBitSequence:
+		return "bit sequence"
+	default:
+		return fmt.Sprintf("unknown kind %d", uint8(k))
+	}
+}
+
+// Value is a generic SCALE value described by a type of the V14 type registry, so it can hold any type the runtime
+// describes without a matching Go struct. Use DecodeWithType and EncodeWithType to convert it from and to bytes.
+//
+// Depending on Kind, only some fields are set:
+//   - Primitive: Primitive holds one of Bool, Text, U8, U16, U32, U64, U128, U256, I8, I16, I32, I64, I128 or I256
+//   - Composite: Fields holds the (possibly unnamed) fields in declaration order
+//   - Variant: VariantName and VariantIndex identify the variant, Fields holds its fields
+//   - Sequence, Array, Tuple: Items holds the elements
+//   - Compact: Primitive holds the decoded UCompact
+//   - BitSequence: Bits holds the decoded bits
+type Value struct {
+	TypeID       int64
+	Kind         ValueKind
+	Primitive    interface{}
+	Fields       []NamedValue
+	VariantName  Text
+	VariantIndex U8
+	Items        []Value
+	Bits         []bool
+}
+
+// NamedValue is a field of a composite or variant Value. Name is empty for tuple-like structs and variants.
+type NamedValue struct {
+	Name     Text
+	TypeName Text
+	Value    Value
+}
+
+// MarshalJSON renders the value the way polkadot.js does: structs as objects (or arrays if their fields are unnamed),
+// variants without fields as their name and other variants as an object keyed by name, and byte sequences as hex.
+func (v Value) MarshalJSON() ([]byte, error) {
+	switch v.Kind {
+	case ValueKindPrimitive:
+		return json.Marshal(v.Primitive)
+	case ValueKindCompact:
+		u, ok := v.Primitive.(UCompact)
+		if !ok {
+			return json.Marshal(v.Primitive)
+		}
+		i := big.Int(u)
+		return i.MarshalJSON()
+	case ValueKindComposite:
+		return marshalFields(v.Fields)
+	case ValueKindVariant:
+		if len(v.Fields) == 0 {
+			return json.Marshal(v.VariantName)
+		}
+		fields, err := marshalFields(v.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(map[string]json.RawMessage{string(v.VariantName): fields})
+	case ValueKindSequence, ValueKindArray, ValueKindTuple:
+		if bz, ok := v.Bytes(); ok {
+			return json.Marshal(HexEncodeToString(bz))
+		}
+		if v.Items == nil {
+			return []byte("[]"), nil
+		}
+		return json.Marshal(v.Items)
+	case ValueKindBitSequence:
+		if v.Bits == nil {
+			return []byte("[]"), nil
+		}
+		return json.Marshal(v.Bits)
+	default:
+		return nil, fmt.Errorf("cannot marshal value of %v", v.Kind)
+	}
+}
+
+func marshalFields(fields []NamedValue) ([]byte, error) {
+	switch {
+	case len(fields) == 1 && fields[0].Name == "":
+		// newtype wrappers such as AccountId32([u8; 32]) are rendered as their inner value
+		return json.Marshal(fields[0].Value)
+	case len(fields) > 0 && fields[0].Name == "":
+		values := make([]Value, len(fields))
+		for i, f := range fields {
+			values[i] = f.Value
+		}
+		return json.Marshal(values)
+	}
+
+	// build the object by hand to keep the field order of the type definition
+	buf := bytes.NewBufferString("{")
+	for i, f := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Bytes returns the content of a sequence or array of u8 as a byte slice. The second return value is false if v is
+// not made up of U8 items.
+func (v Value) Bytes() ([]byte, bool) {
+	if v.Kind != ValueKindSequence && v.Kind != ValueKindArray {
+		return nil, false
+	}
+	bz := make([]byte, len(v.Items))
+	for i, item := range v.Items {
+		u, ok := item.Primitive.(U8)
+		if !ok || item.Kind != ValueKindPrimitive {
+			return nil, false
+		}
+		bz[i] = byte(u)
+	}
+	return bz, true
+}
+
+// Field returns the value of the named field of a composite or variant value
+func (v Value) Field(name string) (Value, bool) {
+	for _, f := range v.Fields {
+		if string(f.Name) == name {
+			return f.Value, true
+		}
+	}
+	return Value{}, false
+}
+
+// FindType returns the type with the given id from the portable registry
+func (d *MetadataV14) FindType(id int64) (*Si1Type, error) {
+	d.ldLk.Lock()
+	t, ok := d.LookUpData[id]
+	d.ldLk.Unlock()
+	if ok {
+		return t, nil
+	}
+
+	// LookUpData is only populated when decoding, fall back to the registry itself for metadata built otherwise
+	for i := range d.Lookup {
+		if d.Lookup[i].Id.Int64() == id {
+			return &d.Lookup[i].Type, nil
+		}
+	}
+	return nil, fmt.Errorf("type %v not found in metadata lookup", id)
+}
+
+// DecodeWithType decodes bz as the type with the given id of the V14 type registry, which is how storage values,
+// constants and call arguments are described in MetadataV14. It errors if bz contains more data than the type.
+func DecodeWithType(meta *MetadataV14, typeID int64, bz []byte) (Value, error) {
+	reader := bytes.NewReader(bz)
+	v, err := meta.DecodeValue(*scale.NewDecoder(reader), typeID)
+	if err != nil {
+		return Value{}, err
+	}
+	if reader.Len() > 0 {
+		return Value{}, fmt.Errorf("%v bytes left after decoding type %v", reader.Len(), typeID)
+	}
+	return v, nil
+}
+
+// EncodeWithType encodes v as the type with the given id of the V14 type registry
+func EncodeWithType(meta *MetadataV14, typeID int64, v Value) ([]byte, error) {
+	var buffer = bytes.Buffer{}
+	err := meta.EncodeValue(*scale.NewEncoder(&buffer), typeID, v)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// DecodeValue decodes the type with the given id from decoder into a Value
+func (d *MetadataV14) DecodeValue(decoder scale.Decoder, id int64) (Value, error) {
+	typ, err := d.FindType(id)
+	if err != nil {
+		return Value{}, err
+	}
+
+	v := Value{TypeID: id}
+	def := typ.Def
+	switch {
+	case def.IsComposite:
+		v.Kind = ValueKindComposite
+		v.Fields, err = d.decodeFields(decoder, def.Composite.Fields)
+		if err != nil {
+			return Value{}, err
+		}
+	case def.IsVariant:
+		v.Kind = ValueKindVariant
+		b, err := decoder.ReadOneByte()
+		if err != nil {
+			return Value{}, err
+		}
+		variant, err := findVariant(def.Variant, b)
+		if err != nil {
+			return Value{}, fmt.Errorf("type %v: %v", id, err)
+		}
+		v.VariantName = variant.Name
+		v.VariantIndex = variant.Index
+		v.Fields, err = d.decodeFields(decoder, variant.Fields)
+		if err != nil {
+			return Value{}, err
+		}
+	case def.IsSequence:
+		v.Kind = ValueKindSequence
+		n, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return Value{}, err
+		}
+		if !n.IsUint64() {
+			return Value{}, fmt.Errorf("sequence length %v out of range", n)
+		}
+		v.Items, err = d.decodeItems(decoder, def.Sequence.Type.Int64(), n.Uint64())
+		if err != nil {
+			return Value{}, err
+		}
+	case def.IsArray:
+		v.Kind = ValueKindArray
+		v.Items, err = d.decodeItems(decoder, def.Array.Type.Int64(), uint64(def.Array.Len))
+		if err != nil {
+			return Value{}, err
+		}
+	case def.IsTuple:
+		v.Kind = ValueKindTuple
+		v.Items = make([]Value, 0, len(def.Tuple))
+		for _, elem := range def.Tuple {
+			item, err := d.DecodeValue(decoder, elem.Int64())
+			if err != nil {
+				return Value{}, err
+			}
+			v.Items = append(v.Items, item)
+		}
+	case def.IsPrimitive:
+		v.Kind = ValueKindPrimitive
+		v.Primitive, err = decodePrimitive(decoder, def.Primitive.Value)
+		if err != nil {
+			return Value{}, err
+		}
+	case def.IsCompact:
+		v.Kind = ValueKindCompact
+		var u UCompact
+		err = decoder.Decode(&u)
+		if err != nil {
+			return Value{}, err
+		}
+		v.Primitive = u
+	case def.IsBitSequence:
+		v.Kind = ValueKindBitSequence
+		v.Bits, err = d.decodeBits(decoder, def.BitSequence)
+		if err != nil {
+			return Value{}, err
+		}
+	default:
+		return Value{}, fmt.Errorf("type %v has an unsupported type definition", id)
+	}
+	return v, nil
+}
+
+// EncodeValue encodes v as the type with the given id. The kind and shape of v must match the type definition.
+func (d *MetadataV14) EncodeValue(encoder scale.Encoder, id int64, v Value) error {
+	typ, err := d.FindType(id)
+	if err != nil {
+		return err
+	}
+
+	def := typ.Def
+	switch {
+	case def.IsComposite:
+		if v.Kind != ValueKindComposite {
+			return fmt.Errorf("type %v is a composite, but value is of kind %v", id, v.Kind)
+		}
+		return d.encodeFields(encoder, id, def.Composite.Fields, v.Fields)
+	case def.IsVariant:
+		if v.Kind != ValueKindVariant {
+			return fmt.Errorf("type %v is a variant, but value is of kind %v", id, v.Kind)
+		}
+		variant, err := findVariantForValue(def.Variant, v)
+		if err != nil {
+			return fmt.Errorf("type %v: %v", id, err)
+		}
+		err = encoder.PushByte(byte(variant.Index))
+		if err != nil {
+			return err
+		}
+		return d.encodeFields(encoder, id, variant.Fields, v.Fields)
+	case def.IsSequence:
+		if v.Kind != ValueKindSequence {
+			return fmt.Errorf("type %v is a sequence, but value is of kind %v", id, v.Kind)
+		}
+		err = encoder.EncodeUintCompact(*new(big.Int).SetUint64(uint64(len(v.Items))))
+		if err != nil {
+			return err
+		}
+		return d.encodeItems(encoder, def.Sequence.Type.Int64(), v.Items)
+	case def.IsArray:
+		if v.Kind != ValueKindArray {
+			return fmt.Errorf("type %v is an array, but value is of kind %v", id, v.Kind)
+		}
+		if len(v.Items) != int(def.Array.Len) {
+			return fmt.Errorf("type %v is an array of length %v, but value has %v items", id, def.Array.Len,
+				len(v.Items))
+		}
+		return d.encodeItems(encoder, def.Array.Type.Int64(), v.Items)
+	case def.IsTuple:
+		if v.Kind != ValueKindTuple {
+			return fmt.Errorf("type %v is a tuple, but value is of kind %v", id, v.Kind)
+		}
+		if len(v.Items) != len(def.Tuple) {
+			return fmt.Errorf("type %v is a tuple of %v elements, but value has %v items", id, len(def.Tuple),
+				len(v.Items))
+		}
+		for i, elem := range def.Tuple {
+			err = d.EncodeValue(encoder, elem.Int64(), v.Items[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case def.IsPrimitive:
+		if v.Kind != ValueKindPrimitive {
+			return fmt.Errorf("type %v is a primitive, but value is of kind %v", id, v.Kind)
+		}
+		expected, err := newPrimitive(def.Primitive.Value)
+		if err != nil {
+			return err
+		}
+		if reflect.TypeOf(v.Primitive) != reflect.TypeOf(expected).Elem() {
+			return fmt.Errorf("type %v is a %v, but value holds %T", id, def.Primitive.Value, v.Primitive)
+		}
+		return encoder.Encode(v.Primitive)
+	case def.IsCompact:
+		if v.Kind != ValueKindCompact {
+			return fmt.Errorf("type %v is a compact, but value is of kind %v", id, v.Kind)
+		}
+		u, ok := v.Primitive.(UCompact)
+		if !ok {
+			return fmt.Errorf("type %v is a compact, but value holds %T", id, v.Primitive)
+		}
+		return encoder.Encode(u)
+	case def.IsBitSequence:
+		if v.Kind != ValueKindBitSequence {
+			return fmt.Errorf("type %v is a bit sequence, but value is of kind %v", id, v.Kind)
+		}
+		return d.encodeBits(encoder, def.BitSequence, v.Bits)
+	default:
+		return fmt.Errorf("type %v has an unsupported type definition", id)
+	}
+}
+
+func (d *MetadataV14) encodeFields(encoder scale.Encoder, id int64, fields []Si1Field, values []NamedValue) error {
+	if len(values) != len(fields) {
+		return fmt.Errorf("type %v has %v fields, but value has %v", id, len(fields), len(values))
+	}
+	for i, f := range fields {
+		err := d.EncodeValue(encoder, f.Type.Int64(), values[i].Value)
+		if err != nil {
+			return fmt.Errorf("unable to encode field %v (%v): %v", f.Name, f.TypeName, err)
+		}
+	}
+	return nil
+}
+
+func (d *MetadataV14) encodeItems(encoder scale.Encoder, id int64, items []Value) error {
+	for _, item := range items {
+		err := d.EncodeValue(encoder, id, item)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *MetadataV14) decodeFields(decoder scale.Decoder, fields []Si1Field) ([]NamedValue, error) {
+	values := make([]NamedValue, 0, len(fields))
+	for _, f := range fields {
+		v, err := d.DecodeValue(decoder, f.Type.Int64())
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode field %v (%v): %v", f.Name, f.TypeName, err)
+		}
+		values = append(values, NamedValue{Name: f.Name, TypeName: f.TypeName, Value: v})
+	}
+	return values, nil
+}
+
+// maxPreallocItems bounds the capacity allocated upfront for decoded items, whose number may come from untrusted input
+const maxPreallocItems = 1024
+
+func (d *MetadataV14) decodeItems(decoder scale.Decoder, id int64, n uint64) ([]Value, error) {
+	capacity := n
+	if capacity > maxPreallocItems {
+		capacity = maxPreallocItems
+	}
+	items := make([]Value, 0, capacity)
+	for i := uint64(0); i < n; i++ {
+		item, err := d.DecodeValue(decoder, id)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// decodeBits decodes a BitVec, which is encoded as the compact number of bits followed by the minimal number of
+// store elements (u8, u16, u32 or u64) needed to hold them. The elements are read one by one, so a number of bits
+// exceeding the input fails with an error before much is allocated.
+func (d *MetadataV14) decodeBits(decoder scale.Decoder, def Si1TypeDefBitSequence) ([]bool, error) {
+	storeSize, msb0, err := d.bitSequenceLayout(def)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := decoder.DecodeUintCompact()
+	if err != nil {
+		return nil, err
+	}
+	if !n.IsUint64() {
+		return nil, fmt.Errorf("bit sequence length %v out of range", n)
+	}
+	bitLen := n.Uint64()
+	storeBits := uint64(storeSize * 8)
+
+	bits := make([]bool, 0)
+	elem := make([]byte, storeSize)
+	for start := uint64(0); start < bitLen; start += storeBits {
+		err = decoder.Read(elem)
+		if err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < storeBits && start+i < bitLen; i++ {
+			// store elements are little endian, the order type defines the bit order within each element
+			bit := i
+			if msb0 {
+				bit = storeBits - 1 - i
+			}
+			bits = append(bits, elem[bit/8]&(1<<(bit%8)) != 0)
+		}
+	}
+	return bits, nil
+}
+
+func (d *MetadataV14) encodeBits(encoder scale.Encoder, def Si1TypeDefBitSequence, bits []bool) error {
+	storeSize, msb0, err := d.bitSequenceLayout(def)
+	if err != nil {
+		return err
+	}
+
+	bitLen := uint64(len(bits))
+	err = encoder.EncodeUintCompact(*new(big.Int).SetUint64(bitLen))
+	if err != nil {
+		return err
+	}
+	storeBits := uint64(storeSize * 8)
+	buf := make([]byte, ((bitLen+storeBits-1)/storeBits)*uint64(storeSize))
+	for i, set := range bits {
+		if !set {
+			continue
+		}
+		elem := uint64(i) / storeBits
+		bit := uint64(i) % storeBits
+		if msb0 {
+			bit = storeBits - 1 - bit
+		}
+		buf[elem*uint64(storeSize)+bit/8] |= 1 << (bit % 8)
+	}
+	return encoder.Write(buf)
+}
+
+func (d *MetadataV14) bitSequenceLayout(def Si1TypeDefBitSequence) (storeSize int, msb0 bool, err error) {
+	store, err := d.FindType(def.BitStoreType.Int64())
+	if err != nil {
+		return 0, false, err
+	}
+	if !store.Def.IsPrimitive {
+		return 0, false, fmt.Errorf("bit store type %v is not a primitive", def.BitStoreType.Int64())
+	}
+	switch store.Def.Primitive.Value {
+	case "U8":
+		storeSize = 1
+	case "U16":
+		storeSize = 2
+	case "U32":
+		storeSize = 4
+	case "U64":
+		storeSize = 8
+	default:
+		return 0, false, fmt.Errorf("unsupported bit store type %v", store.Def.Primitive.Value)
+	}
+
+	order, err := d.FindType(def.BitOrderType.Int64())
+	if err != nil {
+		return 0, false, err
+	}
+	if len(order.Path) == 0 {
+		return 0, false, fmt.Errorf("bit order type %v has no path", def.BitOrderType.Int64())
+	}
+	switch order.Path[len(order.Path)-1] {
+	case "Lsb0":
+		msb0 = false
+	case "Msb0":
+		msb0 = true
+	default:
+		return 0, false, fmt.Errorf("unsupported bit order type %v", order.Path[len(order.Path)-1])
+	}
+	return storeSize, msb0, nil
+}
+
+func findVariant(def Si1TypeDefVariant, index byte) (*Si1Variant, error) {
+	for i := range def.Variants {
+		if uint8(def.Variants[i].Index) == index {
+			return &def.Variants[i], nil
+		}
+	}
+	return nil, fmt.Errorf("variant index %v not found", index)
+}
+
+// findVariantForValue selects the variant of def by the name of v, falling back to its index if no name is set
+func findVariantForValue(def Si1TypeDefVariant, v Value) (*Si1Variant, error) {
+	if v.VariantName == "" {
+		return findVariant(def, byte(v.VariantIndex))
+	}
+	for i := range def.Variants {
+		if def.Variants[i].Name == v.VariantName {
+			return &def.Variants[i], nil
+		}
+	}
+	return nil, fmt.Errorf("variant %v not found", v.VariantName)
+}
+
+// newPrimitive returns a pointer to a zero value of the Go type used to represent the given primitive
+func newPrimitive(primitive string) (interface{}, error) {
+	switch primitive {
+	case "Bool":
+		return new(Bool), nil
+	case "Char":
+		// chars are encoded as their u32 code point
+		return new(U32), nil
+	case "Str":
+		return new(Text), nil
+	case "U8":
+		return new(U8), nil
+	case "U16":
+		return new(U16), nil
+	case "U32":
+		return new(U32), nil
+	case "U64":
+		return new(U64), nil
+	case "U128":
+		return &U128{new(big.Int)}, nil
+	case "U256":
+		return &U256{new(big.Int)}, nil
+	case "I8":
+		return new(I8), nil
+	case "I16":
+		return new(I16), nil
+	case "I32":
+		return new(I32), nil
+	case "I64":
+		return new(I64), nil
+	case "I128":
+		return &I128{new(big.Int)}, nil
+	case "I256":
+		return &I256{new(big.Int)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported primitive type %v", primitive)
+	}
+}
+
+func decodePrimitive(decoder scale.Decoder, primitive string) (interface{}, error) {
+	target, err := newPrimitive(primitive)
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.Decode(target)
+	if err != nil {
+		return nil, err
+	}
+	// return the value rather than the pointer so that callers can type switch on e.g. U32
+	return reflect.ValueOf(target).Elem().Interface(), nil
+}
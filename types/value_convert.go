@@ -0,0 +1,425 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/JFJun/go-substrate-rpc-client/v3/scale"
+)
+
+// NewValue converts the Go value arg into a Value of the type with the given id, so that it is encoded the way the
+// runtime expects it rather than the way its Go type would be encoded. The following conversions are supported:
+//   - Value is used as is
+//   - integer kinds, big.Int, U128, U256, I128, I256, UCompact and decimal strings for integer primitives and compacts,
+//     as long as the number fits into the declared type
+//   - bool for Bool, string and Text for Str
+//   - []byte, Bytes, byte arrays and hex strings for sequences and arrays of u8
+//   - slices and arrays for sequences, arrays and tuples, []bool for bit sequences
+//   - map[string]interface{} keyed by field name for composites, slices for composites with unnamed fields, and the
+//     inner value for composites with a single field such as AccountId32
+//   - the variant name as string for variants without fields, and map[string]interface{} with the variant name as
+//     the only key for variants with fields
+//
+// Other structs and types implementing scale.Encodeable, for example MultiAddress or Call, are encoded with the scale
+// codec and must decode as the declared type.
+func (d *MetadataV14) NewValue(id int64, arg interface{}) (Value, error) {
+	if v, ok := arg.(Value); ok {
+		return v, nil
+	}
+
+	typ, err := d.FindType(id)
+	if err != nil {
+		return Value{}, err
+	}
+
+	v := Value{TypeID: id}
+	def := typ.Def
+	switch {
+	case def.IsPrimitive:
+		v.Kind = ValueKindPrimitive
+		v.Primitive, err = newPrimitiveValue(def.Primitive.Value, arg)
+		if err == nil {
+			return v, nil
+		}
+	case def.IsCompact:
+		v.Kind = ValueKindCompact
+		i, ok := toBigInt(arg)
+		if ok {
+			if i.Sign() < 0 {
+				return Value{}, fmt.Errorf("type %v is a compact, but %v is negative", id, i)
+			}
+			v.Primitive = NewUCompact(i)
+			return v, nil
+		}
+		err = fmt.Errorf("type %v is a compact, but got %T", id, arg)
+	case def.IsComposite:
+		v.Kind = ValueKindComposite
+		v.Fields, err = d.newFields(id, def.Composite.Fields, arg)
+		if err == nil {
+			return v, nil
+		}
+	case def.IsVariant:
+		v.Kind = ValueKindVariant
+		err = d.newVariant(id, def.Variant, arg, &v)
+		if err == nil {
+			return v, nil
+		}
+	case def.IsSequence:
+		v.Kind = ValueKindSequence
+		v.Items, err = d.newItems(id, def.Sequence.Type.Int64(), -1, arg)
+		if err == nil {
+			return v, nil
+		}
+	case def.IsArray:
+		v.Kind = ValueKindArray
+		v.Items, err = d.newItems(id, def.Array.Type.Int64(), int(def.Array.Len), arg)
+		if err == nil {
+			return v, nil
+		}
+	case def.IsTuple:
+		v.Kind = ValueKindTuple
+		v.Items, err = d.newTuple(id, def.Tuple, arg)
+		if err == nil {
+			return v, nil
+		}
+	case def.IsBitSequence:
+		bits, ok := arg.([]bool)
+		if ok {
+			v.Kind = ValueKindBitSequence
+			v.Bits = bits
+			return v, nil
+		}
+		err = fmt.Errorf("type %v is a bit sequence, but got %T", id, arg)
+	default:
+		return Value{}, fmt.Errorf("type %v has an unsupported type definition", id)
+	}
+
+	// fall back to the scale encoding of types such as MultiAddress or Call, which must match the declared type exactly
+	if _, ok := arg.(scale.Encodeable); ok || reflect.ValueOf(arg).Kind() == reflect.Struct {
+		bz, encErr := EncodeToBytes(arg)
+		if encErr != nil {
+			return Value{}, encErr
+		}
+		v, decErr := DecodeWithType(d, id, bz)
+		if decErr != nil {
+			return Value{}, fmt.Errorf("type %v does not match the encoding of %T: %v", id, arg, decErr)
+		}
+		return v, nil
+	}
+	return Value{}, err
+}
+
+func (d *MetadataV14) newFields(id int64, fields []Si1Field, arg interface{}) ([]NamedValue, error) {
+	values := make([]NamedValue, 0, len(fields))
+
+	if m, ok := arg.(map[string]interface{}); ok && (len(fields) == 0 || fields[0].Name != "") {
+		for _, f := range fields {
+			fieldArg, ok := m[string(f.Name)]
+			if !ok {
+				return nil, fmt.Errorf("type %v: missing field %v", id, f.Name)
+			}
+			fv, err := d.NewValue(f.Type.Int64(), fieldArg)
+			if err != nil {
+				return nil, fmt.Errorf("field %v (%v): %v", f.Name, f.TypeName, err)
+			}
+			values = append(values, NamedValue{Name: f.Name, TypeName: f.TypeName, Value: fv})
+		}
+		if len(m) != len(fields) {
+			return nil, fmt.Errorf("type %v has %v fields, but got %v", id, len(fields), len(m))
+		}
+		return values, nil
+	}
+
+	// newtype wrappers such as AccountId32([u8; 32]) take their inner value directly
+	if len(fields) == 1 {
+		fv, err := d.NewValue(fields[0].Type.Int64(), arg)
+		if err != nil {
+			return nil, err
+		}
+		return append(values, NamedValue{Name: fields[0].Name, TypeName: fields[0].TypeName, Value: fv}), nil
+	}
+
+	rv := reflect.ValueOf(arg)
+	if arg == nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return nil, fmt.Errorf("type %v is a composite, but got %T", id, arg)
+	}
+	if rv.Len() != len(fields) {
+		return nil, fmt.Errorf("type %v has %v fields, but got %v", id, len(fields), rv.Len())
+	}
+	for i, f := range fields {
+		fv, err := d.NewValue(f.Type.Int64(), rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("field %v (%v): %v", i, f.TypeName, err)
+		}
+		values = append(values, NamedValue{Name: f.Name, TypeName: f.TypeName, Value: fv})
+	}
+	return values, nil
+}
+
+func (d *MetadataV14) newVariant(id int64, def Si1TypeDefVariant, arg interface{}, v *Value) error {
+	var (
+		name      string
+		fieldsArg interface{}
+	)
+	switch a := arg.(type) {
+	case string:
+		name = a
+	case Text:
+		name = string(a)
+	case map[string]interface{}:
+		if len(a) != 1 {
+			return fmt.Errorf("type %v is a variant and expects a single key, but got %v", id, len(a))
+		}
+		for k, f := range a {
+			name, fieldsArg = k, f
+		}
+	default:
+		return fmt.Errorf("type %v is a variant, but got %T", id, arg)
+	}
+	if name == "" {
+		return fmt.Errorf("type %v is a variant, but got no variant name", id)
+	}
+
+	variant, err := findVariantForValue(def, Value{VariantName: Text(name)})
+	if err != nil {
+		return fmt.Errorf("type %v: %v", id, err)
+	}
+	v.VariantName = variant.Name
+	v.VariantIndex = variant.Index
+	if len(variant.Fields) == 0 {
+		if fieldsArg != nil {
+			return fmt.Errorf("type %v: variant %v has no fields", id, name)
+		}
+		return nil
+	}
+	v.Fields, err = d.newFields(id, variant.Fields, fieldsArg)
+	if err != nil {
+		return fmt.Errorf("variant %v: %v", name, err)
+	}
+	return nil
+}
+
+// newItems converts the elements of a slice or array, n is the required length or -1 for sequences
+func (d *MetadataV14) newItems(id int64, elemID int64, n int, arg interface{}) ([]Value, error) {
+	elem, err := d.FindType(elemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if elem.Def.IsPrimitive && elem.Def.Primitive.Value == "U8" {
+		if bz, ok := toBytes(arg); ok {
+			if n >= 0 && len(bz) != n {
+				return nil, fmt.Errorf("type %v is an array of length %v, but got %v bytes", id, n, len(bz))
+			}
+			items := make([]Value, len(bz))
+			for i, b := range bz {
+				items[i] = Value{TypeID: elemID, Kind: ValueKindPrimitive, Primitive: U8(b)}
+			}
+			return items, nil
+		}
+	}
+
+	rv := reflect.ValueOf(arg)
+	if arg == nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return nil, fmt.Errorf("type %v is a sequence or array, but got %T", id, arg)
+	}
+	if n >= 0 && rv.Len() != n {
+		return nil, fmt.Errorf("type %v is an array of length %v, but got %v items", id, n, rv.Len())
+	}
+	items := make([]Value, rv.Len())
+	for i := range items {
+		items[i], err = d.NewValue(elemID, rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("item %v: %v", i, err)
+		}
+	}
+	return items, nil
+}
+
+func (d *MetadataV14) newTuple(id int64, def Si1TypeDefTuple, arg interface{}) ([]Value, error) {
+	rv := reflect.ValueOf(arg)
+	if arg == nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) {
+		return nil, fmt.Errorf("type %v is a tuple, but got %T", id, arg)
+	}
+	if rv.Len() != len(def) {
+		return nil, fmt.Errorf("type %v is a tuple of %v elements, but got %v", id, len(def), rv.Len())
+	}
+	items := make([]Value, len(def))
+	for i, elem := range def {
+		var err error
+		items[i], err = d.NewValue(elem.Int64(), rv.Index(i).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("element %v: %v", i, err)
+		}
+	}
+	return items, nil
+}
+
+func newPrimitiveValue(primitive string, arg interface{}) (interface{}, error) {
+	switch primitive {
+	case "Bool":
+		switch b := arg.(type) {
+		case bool:
+			return NewBool(b), nil
+		case Bool:
+			return b, nil
+		}
+		return nil, fmt.Errorf("expected a Bool, but got %T", arg)
+	case "Str":
+		switch s := arg.(type) {
+		case string:
+			return NewText(s), nil
+		case Text:
+			return s, nil
+		}
+		return nil, fmt.Errorf("expected a Str, but got %T", arg)
+	}
+
+	i, ok := toBigInt(arg)
+	if !ok {
+		return nil, fmt.Errorf("expected a %v, but got %T", primitive, arg)
+	}
+
+	var (
+		bits   int
+		signed bool
+	)
+	switch primitive {
+	case "U8":
+		bits = 8
+	case "U16":
+		bits = 16
+	case "U32", "Char":
+		bits = 32
+	case "U64":
+		bits = 64
+	case "U128":
+		bits = 128
+	case "U256":
+		bits = 256
+	case "I8":
+		bits, signed = 8, true
+	case "I16":
+		bits, signed = 16, true
+	case "I32":
+		bits, signed = 32, true
+	case "I64":
+		bits, signed = 64, true
+	case "I128":
+		bits, signed = 128, true
+	case "I256":
+		bits, signed = 256, true
+	default:
+		return nil, fmt.Errorf("unsupported primitive type %v", primitive)
+	}
+
+	if !fitsInto(i, bits, signed) {
+		return nil, fmt.Errorf("%v does not fit into a %v", i, primitive)
+	}
+
+	switch primitive {
+	case "U8":
+		return NewU8(uint8(i.Uint64())), nil
+	case "U16":
+		return NewU16(uint16(i.Uint64())), nil
+	case "U32", "Char":
+		return NewU32(uint32(i.Uint64())), nil
+	case "U64":
+		return NewU64(i.Uint64()), nil
+	case "U128":
+		return NewU128(*i), nil
+	case "U256":
+		return NewU256(*i), nil
+	case "I8":
+		return NewI8(int8(i.Int64())), nil
+	case "I16":
+		return NewI16(int16(i.Int64())), nil
+	case "I32":
+		return NewI32(int32(i.Int64())), nil
+	case "I64":
+		return NewI64(i.Int64()), nil
+	case "I128":
+		return NewI128(*i), nil
+	default:
+		return NewI256(*i), nil
+	}
+}
+
+func fitsInto(i *big.Int, bits int, signed bool) bool {
+	if !signed {
+		return i.Sign() >= 0 && i.BitLen() <= bits
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+	return i.Cmp(new(big.Int).Neg(limit)) >= 0 && i.Cmp(limit) < 0
+}
+
+// toBigInt converts integer kinds, the big integer types of this package and decimal strings into a big.Int
+func toBigInt(arg interface{}) (*big.Int, bool) {
+	switch a := arg.(type) {
+	case *big.Int:
+		if a == nil {
+			return nil, false
+		}
+		return new(big.Int).Set(a), true
+	case big.Int:
+		return new(big.Int).Set(&a), true
+	case UCompact:
+		i := big.Int(a)
+		return new(big.Int).Set(&i), true
+	case U128:
+		return toBigInt(a.Int)
+	case U256:
+		return toBigInt(a.Int)
+	case I128:
+		return toBigInt(a.Int)
+	case I256:
+		return toBigInt(a.Int)
+	case string:
+		return new(big.Int).SetString(a, 10)
+	}
+
+	rv := reflect.ValueOf(arg)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), true
+	}
+	return nil, false
+}
+
+// toBytes converts byte slices, byte arrays such as AccountID and Hash, and hex strings into a byte slice
+func toBytes(arg interface{}) ([]byte, bool) {
+	if s, ok := arg.(string); ok {
+		bz, err := HexDecodeString(s)
+		return bz, err == nil
+	}
+
+	rv := reflect.ValueOf(arg)
+	if arg == nil || (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) ||
+		rv.Type().Elem().Kind() != reflect.Uint8 {
+		return nil, false
+	}
+	bz := make([]byte, rv.Len())
+	for i := range bz {
+		bz[i] = byte(rv.Index(i).Uint())
+	}
+	return bz, true
+}
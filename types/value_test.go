@@ -0,0 +1,133 @@
+// Go Substrate RPC Client (GSRPC) provides APIs and types around Polkadot and any Substrate-based chain RPC calls
+//
+// Copyright 2019 Centrifuge GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	. "github.com/JFJun/go-substrate-rpc-client/v3/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// type ids of the polkadot metadata in MetadataV14Data
+const (
+	exampleAccountInfoTypeID = 3
+	exampleBalanceTypeID     = 6
+	exampleBitVecTypeID      = 318
+)
+
+// AccountInfo with nonce 1, a single provider and a free balance of 1 DOT
+var exampleAccountInfoEnc = MustHexDecodeString("0x01000000" + "00000000" + "01000000" + "00000000" +
+	"0010a5d4e8000000000000000000000000000000000000000000000000000000" +
+	"0000000000000000000000000000000000000000000000000000000000000000")
+
+func exampleMetadataV14(t *testing.T) *MetadataV14 {
+	var meta Metadata
+	err := DecodeFromHexString(MetadataV14Data, &meta)
+	assert.NoError(t, err)
+	return &meta.AsMetadataV14
+}
+
+func TestDecodeWithType_EncodeWithType(t *testing.T) {
+	meta := exampleMetadataV14(t)
+
+	v, err := DecodeWithType(meta, exampleAccountInfoTypeID, exampleAccountInfoEnc)
+	assert.NoError(t, err)
+	assert.Equal(t, ValueKindComposite, v.Kind)
+
+	nonce, ok := v.Field("nonce")
+	assert.True(t, ok)
+	assert.Equal(t, U32(1), nonce.Primitive)
+	data, ok := v.Field("data")
+	assert.True(t, ok)
+	free, ok := data.Field("free")
+	assert.True(t, ok)
+	assert.Equal(t, NewU128(*big.NewInt(1000000000000)), free.Primitive)
+
+	enc, err := EncodeWithType(meta, exampleAccountInfoTypeID, v)
+	assert.NoError(t, err)
+	assert.Equal(t, exampleAccountInfoEnc, enc)
+}
+
+func TestDecodeWithType_BitSequence(t *testing.T) {
+	meta := exampleMetadataV14(t)
+	enc := MustHexDecodeString("0x240d01")
+
+	v, err := DecodeWithType(meta, exampleBitVecTypeID, enc)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true, true, false, false, false, false, true}, v.Bits)
+
+	reenc, err := EncodeWithType(meta, exampleBitVecTypeID, v)
+	assert.NoError(t, err)
+	assert.Equal(t, enc, reenc)
+}
+
+func TestDecodeWithType_HugeLength(t *testing.T) {
+	meta := exampleMetadataV14(t)
+	var seqTypeID int64 = -1
+	for _, lookUp := range meta.Lookup {
+		if lookUp.Type.Def.IsSequence {
+			seqTypeID = lookUp.Id.Int64()
+			break
+		}
+	}
+	assert.NotEqual(t, int64(-1), seqTypeID)
+
+	// lengths of 2^62 - 1 fail on the missing input instead of allocating for them upfront
+	huge := MustHexDecodeString("0x13ffffffffffffff3f")
+	_, err := DecodeWithType(meta, seqTypeID, huge)
+	assert.Error(t, err)
+	_, err = DecodeWithType(meta, exampleBitVecTypeID, append(huge, 0x01))
+	assert.Error(t, err)
+
+	tooLarge := MustHexDecodeString("0x17ffffffffffffffffff")
+	_, err = DecodeWithType(meta, seqTypeID, tooLarge)
+	assert.EqualError(t, err, "sequence length 4722366482869645213695 out of range")
+	_, err = DecodeWithType(meta, exampleBitVecTypeID, tooLarge)
+	assert.EqualError(t, err, "bit sequence length 4722366482869645213695 out of range")
+}
+
+func TestDecodeWithType_TrailingBytes(t *testing.T) {
+	meta := exampleMetadataV14(t)
+
+	_, err := DecodeWithType(meta, exampleBalanceTypeID, make([]byte, 17))
+	assert.EqualError(t, err, "1 bytes left after decoding type 6")
+}
+
+func TestEncodeWithType_Mismatch(t *testing.T) {
+	meta := exampleMetadataV14(t)
+
+	_, err := EncodeWithType(meta, exampleBalanceTypeID, Value{Kind: ValueKindPrimitive, Primitive: U32(1)})
+	assert.EqualError(t, err, "type 6 is a U128, but value holds types.U32")
+
+	_, err = EncodeWithType(meta, exampleBalanceTypeID, Value{Kind: ValueKindSequence})
+	assert.EqualError(t, err, "type 6 is a primitive, but value is of kind sequence")
+}
+
+func TestValue_MarshalJSON(t *testing.T) {
+	meta := exampleMetadataV14(t)
+
+	v, err := DecodeWithType(meta, exampleAccountInfoTypeID, exampleAccountInfoEnc)
+	assert.NoError(t, err)
+
+	bz, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"nonce":1,"consumers":0,"providers":1,"sufficients":0,`+
+		`"data":{"free":1000000000000,"reserved":0,"misc_frozen":0,"fee_frozen":0}}`, string(bz))
+}